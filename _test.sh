@@ -1,4 +1,4 @@
-FILES=$(ls *.go)
+FILES=$(ls *.go sudoku/*.go)
 
 echo "Checking gofmt..."
 fmtRes=$(gofmt -l -s -d $FILES)
@@ -21,7 +21,7 @@ if [ -n "${errRes}" ]; then
 fi
 
 echo "Checking govet..."
-go vet $FILES
+go vet ./...
 if [ $? -ne 0 ]; then
     exit 255
 fi
@@ -53,22 +53,22 @@ if [ -f cover.out ]; then
     rm cover.out
 fi
 
-go test -timeout 3m --race -cpu 1
+go test -timeout 3m --race -cpu 1 ./...
 if [ $? -ne 0 ]; then
     exit 255
 fi
 
-go test -timeout 3m --race -cpu 2
+go test -timeout 3m --race -cpu 2 ./...
 if [ $? -ne 0 ]; then
     exit 255
 fi
 
-go test -timeout 3m --race -cpu 4
+go test -timeout 3m --race -cpu 4 ./...
 if [ $? -ne 0 ]; then
     exit 255
 fi
 
-go test -timeout 3m -coverprofile cover.out
+go test -timeout 3m -coverprofile cover.out ./sudoku
 if [ $? -ne 0 ]; then
     exit 255
 fi
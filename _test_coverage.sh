@@ -1,6 +1,6 @@
 if [ ! -f cover.out ]; then
     echo "Running tests..."
-    go test -timeout 3m -coverprofile cover.out
+    go test -timeout 3m -coverprofile cover.out ./sudoku
     if [ $? -ne 0 ]; then
         exit 255
     fi
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gerred/go-sudoku/sudoku"
+)
+
+// minimalJSON is the -format json representation of a -check-minimal result.
+type minimalJSON struct {
+	Puzzle         string `json:"puzzle"`
+	Minimal        bool   `json:"minimal"`
+	RedundantClues []int  `json:"redundantClues"`
+	Time           string `json:"time,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// checkMinimalItem reports whether the puzzle on line i is minimal, listing
+// the clues which could be removed if it is not. In text form a line such
+// as "<puzzle> redundant: A1=5 C7=2" is written per puzzle.
+func checkMinimalItem(i int, line string, opts outputOptions) listResult {
+	result := listResult{index: i}
+	start := time.Now()
+
+	var list []int
+	board, err := sudoku.LoadBoard([]byte(line))
+	if err == nil {
+		list, err = board.RedundantClues()
+	}
+	if err != nil {
+		result.err = fmt.Errorf("puz=%d err=%q", i+1, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if opts.format == formatJSON {
+		puzzle := minimalJSON{Puzzle: line, Minimal: err == nil && len(list) == 0, RedundantClues: []int{}}
+		if list != nil {
+			puzzle.RedundantClues = list
+		}
+		if err != nil {
+			puzzle.Error = err.Error()
+		}
+		if opts.showSolveTime {
+			puzzle.Time = time.Since(start).String()
+		}
+		if err = writeJSON(buf, puzzle); err != nil {
+			result.err = err
+		}
+		result.output = buf.Bytes()
+		return result
+	}
+
+	switch {
+	case result.err != nil:
+		fmt.Fprintf(buf, "%s ERROR - %s\n", line, result.err)
+	case len(list) == 0:
+		fmt.Fprintf(buf, "%s minimal\n", line)
+	default:
+		clues := make([]string, len(list))
+		for j, pos := range list {
+			clues[j] = fmt.Sprintf("%s=%d", sudoku.CellName(pos), board.Value(pos/9, pos%9))
+		}
+		fmt.Fprintf(buf, "%s redundant: %s\n", line, strings.Join(clues, " "))
+	}
+	if opts.showSolveTime {
+		fmt.Fprintf(buf, "puz=%d time=%v\n", i+1, time.Since(start))
+	}
+
+	result.output = buf.Bytes()
+	return result
+}
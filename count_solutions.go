@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gerred/go-sudoku/sudoku"
+)
+
+// countSolutionsFlag is the max of -count-solutions. It may be given
+// without a value, "-count-solutions", or with one, "-count-solutions=10".
+type countSolutionsFlag int
+
+const defaultMaxSolutions = 2
+
+func (f *countSolutionsFlag) String() string {
+	return strconv.Itoa(int(*f))
+}
+
+func (f *countSolutionsFlag) Set(s string) error {
+	switch s {
+	case "true":
+		*f = defaultMaxSolutions
+		return nil
+	case "false":
+		*f = 0
+		return nil
+	}
+
+	max, err := strconv.Atoi(s)
+	if err != nil || max < 1 {
+		return fmt.Errorf("expected a max number of solutions of at least 1, actual: %q", s)
+	}
+	*f = countSolutionsFlag(max)
+	return nil
+}
+
+func (f *countSolutionsFlag) IsBoolFlag() bool {
+	return true
+}
+
+// solutionsJSON is the -format json representation of a -count-solutions result.
+type solutionsJSON struct {
+	Puzzle    string   `json:"puzzle"`
+	Count     int      `json:"count"`
+	AtLeast   bool     `json:"atLeast"`
+	Solutions []string `json:"solutions,omitempty"`
+	Time      string   `json:"time,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// countSolutionsItem counts the solutions of the puzzle on line i up to max.
+// In text form "<puzzle> solutions=1" is written, or "solutions=at least N"
+// when max is reached, followed by the solutions if printSolutions is set.
+func countSolutionsItem(i int, line string, max int, printSolutions bool, opts outputOptions) listResult {
+	result := listResult{index: i}
+	start := time.Now()
+
+	var list []string
+	board, err := sudoku.LoadBoard([]byte(line))
+	if err == nil {
+		board.SetBackend(opts.backend)
+		list, err = board.Solutions(max)
+	} else if _, ok := err.(sudoku.ErrUnsolvable); ok {
+		// clues which conflict, no solutions
+		err = nil
+	}
+	if err != nil {
+		result.err = fmt.Errorf("puz=%d err=%q", i+1, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if opts.format == formatJSON {
+		puzzle := solutionsJSON{Puzzle: line, Count: len(list), AtLeast: len(list) == max}
+		if printSolutions {
+			puzzle.Solutions = list
+		}
+		if err != nil {
+			puzzle.Error = err.Error()
+		}
+		if opts.showSolveTime {
+			puzzle.Time = time.Since(start).String()
+		}
+		if err = writeJSON(buf, puzzle); err != nil {
+			result.err = err
+		}
+		result.output = buf.Bytes()
+		return result
+	}
+
+	switch {
+	case result.err != nil:
+		fmt.Fprintf(buf, "%s ERROR - %s\n", line, result.err)
+	case len(list) == max:
+		fmt.Fprintf(buf, "%s solutions=at least %d\n", line, max)
+	default:
+		fmt.Fprintf(buf, "%s solutions=%d\n", line, len(list))
+	}
+	if printSolutions {
+		for _, solution := range list {
+			fmt.Fprintf(buf, "%s\n", solution)
+		}
+	}
+	if opts.showSolveTime {
+		fmt.Fprintf(buf, "puz=%d time=%v\n", i+1, time.Since(start))
+	}
+
+	result.output = buf.Bytes()
+	return result
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/gerred/go-sudoku/sudoku"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+
+	modeHuman = "human"
+	modeBrute = "brute"
+)
+
+type outputOptions struct {
+	format        string
+	mode          string
+	showSteps     bool
+	showSolveTime bool
+	backend       sudoku.Backend
+	assumeUnique  bool
+}
+
+// puzzleJSON is the -format json representation of a solved or generated puzzle.
+type puzzleJSON struct {
+	Puzzle     string        `json:"puzzle"`
+	Solution   string        `json:"solution,omitempty"`
+	Unique     bool          `json:"unique"`
+	Difficulty int           `json:"difficulty"`
+	Techniques []string      `json:"techniques"`
+	Steps      []sudoku.Step `json:"steps,omitempty"`
+	Seed       int64         `json:"seed,omitempty"`
+	Time       string        `json:"time,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// newPuzzleJSON builds the JSON result for puzzle, given the board it was
+// solved on (may be nil if loading failed) and the error returned by Solve.
+func newPuzzleJSON(puzzle string, b *sudoku.Board, solveErr error, opts outputOptions, elapsed time.Duration) puzzleJSON {
+	result := puzzleJSON{Puzzle: puzzle, Techniques: []string{}}
+	if opts.showSolveTime {
+		result.Time = elapsed.String()
+	}
+
+	if b != nil {
+		if techniques := b.TechniquesUsed(); techniques != nil {
+			result.Techniques = techniques
+		}
+		if trace := b.Trace(); opts.showSteps && trace != nil {
+			result.Steps = trace.Steps
+		}
+		result.Difficulty = b.Difficulty()
+	}
+
+	if solveErr != nil {
+		result.Error = solveErr.Error()
+		return result
+	}
+	if b == nil || !b.IsSolved() {
+		result.Error = "could not solve"
+		return result
+	}
+
+	result.Solution = b.GetCompact()
+
+	original, err := sudoku.LoadBoard([]byte(puzzle))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	original.SetBackend(opts.backend)
+	if result.Unique, err = original.HasUniqueSolution(); err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// newGeneratedPuzzleJSON builds the JSON result for the generated board b,
+// solving its puzzle again to record the solution and steps.
+func newGeneratedPuzzleJSON(b *sudoku.Board, seed int64, opts outputOptions, elapsed time.Duration) puzzleJSON {
+	puzzle := b.GetCompact()
+	b, err := sudoku.LoadBoard([]byte(puzzle))
+	if err == nil {
+		b.SetRecordSteps(true)
+		b.SetBackend(opts.backend)
+		// as when grading it, generated puzzles are unique
+		b.SetAssumeUnique(true)
+		err = b.Solve()
+	}
+	result := newPuzzleJSON(puzzle, b, err, opts, elapsed)
+	result.Seed = seed
+	return result
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
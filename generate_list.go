@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gerred/go-sudoku/sudoku"
+)
+
+type generateResult struct {
+	index     int
+	seed      int64
+	puzzle    string
+	canonical string
+	board     *sudoku.Board
+	json      puzzleJSON
+	err       error
+}
+
+// generateListOptions control the lines written by runGenerateList. JSON
+// lines are the objects of a single -generate, always with the seed.
+type generateListOptions struct {
+	outputOptions
+	showGrade bool
+	showSeed  bool
+}
+
+// generateListAttempts is the attempt budget of each puzzle when genOpts
+// has none. A seed which runs out is skipped for the next one.
+const generateListAttempts = 10
+
+// generateListMisses is the number of consecutive seeds without a new
+// puzzle, out of budget or duplicates, after which runGenerateList gives up
+// on options too hard or too narrow to meet.
+var generateListMisses = 50
+
+// runGenerateList generates count distinct puzzles using a pool of workers
+// and writes them to w, one line each. Every puzzle gets its own seed drawn
+// from seed, so it can be regenerated alone with -seed and the same
+// options. Puzzles equivalent to one already written under the Sudoku
+// symmetries are skipped. Results are written in seed order, which keeps
+// the output for a given seed the same regardless of the number of workers.
+func runGenerateList(w io.Writer, count, workers int, seed int64, genOpts sudoku.GenerateOptions, opts generateListOptions) error {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if genOpts.MaxAttempts <= 0 {
+		genOpts.MaxAttempts = generateListAttempts
+	}
+
+	seeds := rand.New(rand.NewSource(seed))
+	done := make(chan struct{})
+	jobs := make(chan generateResult)
+	results := make(chan generateResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results <- generateListItem(job, genOpts, opts)
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+		for i := 0; ; i++ {
+			job := generateResult{index: i, seed: seeds.Int63()}
+			for job.seed == 0 {
+				// 0 asks for a random seed on the command line
+				job.seed = seeds.Int63()
+			}
+			select {
+			case jobs <- job:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// write results in seed order as they become available
+	var err error
+	pending := make(map[int]generateResult)
+	seen := make(map[string]interface{})
+	next := 0
+	written := 0
+	misses := 0
+	for result := range results {
+		if err != nil || written == count {
+			// draining after the last puzzle or an error
+			continue
+		}
+
+		pending[result.index] = result
+		for err == nil && written < count {
+			cur, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			if cur.err != nil && cur.err != sudoku.ErrGenerateBudget {
+				err = cur.err
+				break
+			}
+
+			if cur.err == nil {
+				if _, ok = seen[cur.canonical]; !ok {
+					seen[cur.canonical] = struct{}{}
+					if err = writeGenerateResult(w, cur, opts); err != nil {
+						break
+					}
+					written++
+					misses = 0
+					continue
+				}
+			}
+
+			// give up rather than loop forever on options too hard to meet
+			if misses++; misses == generateListMisses {
+				err = fmt.Errorf("no new puzzle from %d seeds in a row, %d of %d written", misses, written, count)
+				break
+			}
+		}
+
+		if err != nil || written == count {
+			close(done)
+		}
+	}
+
+	return err
+}
+
+func generateListItem(job generateResult, genOpts sudoku.GenerateOptions, opts generateListOptions) generateResult {
+	start := time.Now()
+	genOpts.Rand = rand.New(rand.NewSource(job.seed))
+	job.board, job.err = sudoku.Generate(genOpts)
+	if job.err != nil {
+		return job
+	}
+	job.puzzle = job.board.GetCompact()
+	job.canonical = job.board.Canonical()
+	if opts.format == formatJSON {
+		// solved here rather than when written, to use all the workers
+		job.json = newGeneratedPuzzleJSON(job.board, job.seed, opts.outputOptions, time.Since(start))
+	}
+	return job
+}
+
+func writeGenerateResult(w io.Writer, result generateResult, opts generateListOptions) error {
+	if opts.format == formatJSON {
+		return writeJSON(w, result.json)
+	}
+
+	buf := bytes.NewBufferString(result.puzzle)
+	if opts.showGrade {
+		fmt.Fprintf(buf, " difficulty=%d", result.board.Difficulty())
+	}
+	if opts.showSeed {
+		fmt.Fprintf(buf, " seed=%d", result.seed)
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// generateList runs runGenerateList, writing to fileName or stdout if empty.
+func generateList(fileName string, count, workers int, seed int64, genOpts sudoku.GenerateOptions, opts generateListOptions) error {
+	if fileName == "" {
+		return runGenerateList(os.Stdout, count, workers, seed, genOpts, opts)
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err = runGenerateList(w, count, workers, seed, genOpts, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
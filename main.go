@@ -1,36 +1,68 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
 	"flag"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"log"
 	"math/rand"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/gerred/go-sudoku/sudoku"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
+// seededGenerateAttempts is the default -generate-attempts with -seed,
+// which can't use -generate-timeout to give up. Options which can't be met
+// give up in about the same time as the default -generate-timeout.
+const seededGenerateAttempts = 100
 
 func main() {
 	flags := flag.FlagSet{}
 	profile := flags.Bool("profile", false, "profile cpu/mem, creates go-sudoku.pprof and go-sudoku.mprof")
 	runFile := flags.String("file", "", "bulk run puzzle(s) in compact 81-char form")
 	maxPuzzles := flags.Int("max-puzzles", -1, "max puzzles to solve when multiple present in a file")
+	workers := flags.Int("workers", 1, "number of puzzles to solve or generate concurrently with -file or -generate -count, 0 uses all CPUs")
 	showSteps := flags.Bool("steps", false, "show solve steps")
 	showSolveTime := flags.Bool("time", false, "print time taken to solve or generate")
-	generate := flags.Bool("generate", false, "generate a random puzzle with 1 unique solution (unfortnately no difficulty selection)")
+	generate := flags.Bool("generate", false, "generate a random puzzle with 1 unique solution")
+	difficulty := flags.String("difficulty", "", "use with -generate: easy, medium, hard, expert or a range such as 20-40")
+	requireTechniques := flags.String("require-techniques", "", "use with -generate: comma separated techniques the solve must use, e.g. \"X-WING,Y-WING\"")
+	maxTechnique := flags.String("max-technique", "", "use with -generate: hardest technique allowed, e.g. \"SWORDFISH\"")
+	symmetry := flags.String("symmetry", "auto", "use with -generate: none, rotational, vertical, horizontal, diagonal, rotational90, dihedral or auto")
+	minimal := flags.Bool("minimal", false, "use with -generate: generate a minimal puzzle, from which no clue can be removed")
+	var countSolutions countSolutionsFlag
+	flags.Var(&countSolutions, "count-solutions", "count the solutions of the puzzle(s), reporting 0, 1 or \"at least max\"; -count-solutions=max sets max (default 2)")
+	printSolutions := flags.Bool("print-solutions", false, "use with -count-solutions: print the distinct solutions found")
+	checkMinimal := flags.Bool("check-minimal", false, "check whether the puzzle(s) are minimal and list the redundant clues")
+	generateAttempts := flags.Int("generate-attempts", 0, "use with -generate: max puzzles to try before giving up, 0 for no limit; the default is 100 with -seed, which doesn't allow 0, and 10 per puzzle with -count")
+	generateTimeout := flags.Duration("generate-timeout", time.Minute, "use with -generate: max time to search for a puzzle, 0 for no limit; not used with -seed")
+	count := flags.Int("count", 1, "use with -generate: number of distinct puzzles to generate, one compact line each when more than 1")
+	outFile := flags.String("out", "", "use with -generate -count: write the puzzles to a file instead of stdout")
+	showGrade := flags.Bool("show-grade", false, "use with -generate -count: append difficulty=N to each line")
+	showSeed := flags.Bool("show-seed", false, "use with -generate -count: append seed=N to each line, to regenerate the puzzle alone with -seed")
+	seed := flags.Int64("seed", 0, "use with -generate: random seed, the same seed and options generate the same puzzle, so only -generate-attempts (default 100 with -seed) limits the search; 0 picks a seed")
+	format := flags.String("format", formatText, "output format: text or json")
+	mode := flags.String("mode", modeHuman, "solve mode: human (techniques, falling back on -backend) or brute (fast backtracking, no steps)")
+	backend := flags.String("backend", "sat", "brute-force solver used when human techniques are stuck and to count solutions: sat or dlx")
+	assumeUnique := flags.Bool("assume-unique", false, "solve assuming the puzzle has a unique solution, enabling uniqueness techniques such as unique rectangles")
 
 	var err error
 	if err := flags.Parse(os.Args[1:]); err != nil {
 		log.Fatal(err)
 	}
 
+	if *format != formatText && *format != formatJSON {
+		log.Fatalf("unknown format %q, expected %q or %q", *format, formatText, formatJSON)
+	}
+	if *mode != modeHuman && *mode != modeBrute {
+		log.Fatalf("unknown mode %q, expected %q or %q", *mode, modeHuman, modeBrute)
+	}
+	opts := outputOptions{format: *format, mode: *mode, showSteps: *showSteps, showSolveTime: *showSolveTime, assumeUnique: *assumeUnique}
+	if opts.backend, err = sudoku.ParseBackend(*backend); err != nil {
+		log.Fatal(err)
+	}
+
 	if *profile {
 		if err = startProfiler(); err != nil {
 			log.Fatal(err)
@@ -39,7 +71,7 @@ func main() {
 
 	var start time.Time
 	defer func() {
-		if *showSolveTime {
+		if *showSolveTime && *format == formatText {
 			fmt.Printf("time=%v\n", time.Since(start))
 		}
 		if *profile {
@@ -51,10 +83,69 @@ func main() {
 
 	if *generate {
 		start = time.Now()
-		b, err := generatePuzzle(0, 0)
+		bulk := *count > 1 || *outFile != ""
+		timeout := *generateTimeout
+		if *seed == 0 {
+			*seed = start.UnixNano()
+		} else {
+			// a deadline would make the puzzle depend on timing, so only
+			// a finite number of attempts can stop a search which fails
+			attemptsSet := false
+			flags.Visit(func(f *flag.Flag) {
+				switch f.Name {
+				case "generate-timeout":
+					log.Fatal("-generate-timeout can't be combined with -seed, use -generate-attempts")
+				case "generate-attempts":
+					attemptsSet = true
+				}
+			})
+			timeout = 0
+			if !attemptsSet && !bulk {
+				// runGenerateList has its own budget for each puzzle
+				*generateAttempts = seededGenerateAttempts
+			} else if attemptsSet && *generateAttempts <= 0 {
+				log.Fatal("-seed needs a limit, set -generate-attempts to at least 1")
+			}
+		}
+		genOpts := sudoku.GenerateOptions{
+			MaxTechnique: *maxTechnique,
+			Minimal:      *minimal,
+			MaxAttempts:  *generateAttempts,
+			Timeout:      timeout,
+			Rand:         rand.New(rand.NewSource(*seed)),
+		}
+		if genOpts.Symmetry, err = sudoku.ParseSymmetry(*symmetry); err != nil {
+			log.Fatal(err)
+		}
+		if *requireTechniques != "" {
+			for _, name := range strings.Split(*requireTechniques, ",") {
+				genOpts.RequiredTechniques = append(genOpts.RequiredTechniques, strings.TrimSpace(name))
+			}
+		}
+		if *difficulty != "" {
+			if genOpts.MinDifficulty, genOpts.MaxDifficulty, err = sudoku.ParseDifficulty(*difficulty); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		if bulk {
+			if err = generateList(*outFile, *count, *workers, *seed, genOpts,
+				generateListOptions{outputOptions: opts, showGrade: *showGrade, showSeed: *showSeed}); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		b, err := sudoku.Generate(genOpts)
 		if err != nil {
 			log.Fatal(err)
 		}
+		if *format == formatJSON {
+			if err = writeJSON(os.Stdout, newGeneratedPuzzleJSON(b, *seed, opts, time.Since(start))); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
 		b.Print()
 		return
 	}
@@ -62,27 +153,58 @@ func main() {
 	if *runFile == "" {
 		// read board from stdin (before starting timer)
 		var boardBytes []byte
-		if boardBytes, err = readBoard(os.Stdin); err != nil {
+		if boardBytes, err = sudoku.ReadBoard(os.Stdin); err != nil {
 			log.Fatal(err)
 		}
 
 		start = time.Now()
 
-		var b *board
-		if b, err = loadBoard(boardBytes); err != nil {
-			if _, ok := err.(ErrUnsolvable); ok {
+		if *checkMinimal || countSolutions != 0 {
+			// the total time is printed on exit in text mode
+			itemOpts := opts
+			itemOpts.showSolveTime = *showSolveTime && *format == formatJSON
+			var result listResult
+			if *checkMinimal {
+				result = checkMinimalItem(0, string(boardBytes), itemOpts)
+			} else {
+				result = countSolutionsItem(0, string(boardBytes), int(countSolutions), *printSolutions, itemOpts)
+			}
+			if _, err = os.Stdout.Write(result.output); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		var b *sudoku.Board
+		if b, err = sudoku.LoadBoard(boardBytes); err != nil {
+			if *format == formatJSON {
+				if err = writeJSON(os.Stdout, newPuzzleJSON(string(boardBytes), nil, err, opts, time.Since(start))); err != nil {
+					log.Fatal(err)
+				}
+				return
+			}
+			if _, ok := err.(sudoku.ErrUnsolvable); ok {
 				fmt.Printf("UNSOLVABLE\n")
 				return
 			}
 			log.Fatal(err)
 		}
 
-		if *showSteps {
-			b.showSteps = true
+		puzzle := b.GetCompact()
+		b.SetRecordSteps(*showSteps || *format == formatJSON)
+		b.SetBackend(opts.backend)
+
+		err = solveBoard(b, opts)
+		if *format == formatJSON {
+			if err = writeJSON(os.Stdout, newPuzzleJSON(puzzle, b, err, opts, time.Since(start))); err != nil {
+				log.Fatal(err)
+			}
+			return
 		}
 
-		if err = b.Solve(); err != nil {
-			if _, ok := err.(ErrUnsolvable); ok {
+		b.Trace().Print(os.Stdout)
+		if err != nil {
+			if _, ok := err.(sudoku.ErrUnsolvable); ok {
 				fmt.Printf("UNSOLVABLE\n")
 				return
 			}
@@ -93,87 +215,16 @@ func main() {
 	} else {
 		// read compact board(s) from file
 		start = time.Now()
-		if err := runList(*runFile, *maxPuzzles, *showSolveTime, *showSteps); err != nil {
-			log.Fatal(err)
-		}
-	}
-}
-
-func runList(fileName string, maxPuzzles int, showSolveTime, showSteps bool) error {
-	b, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		return err
-	}
-
-	r := bufio.NewReader(bytes.NewReader(b))
-	line, err := r.ReadString('\n')
-	if err != nil && err != io.EOF {
-		return err
-	}
-	for i := 0; line != "" && (maxPuzzles == -1 || i < maxPuzzles); i++ {
-		firstLog = true
-		logLastStepReducedHints = false
-		logLastBoardWithHints = ""
-		logLastHeader = ""
-
-		fmt.Printf("-----------------\nPuzzle # %d:\n", i+1)
-		start1 := time.Now()
-		board, err := loadBoard([]byte(line))
-		if err != nil {
-			if board != nil {
-				board.PrintHints()
-			}
-			return fmt.Errorf("puz=%d err=%q", i+1, err)
-		}
-
-		board.showSteps = showSteps
-
-		if err = board.Solve(); err != nil {
-			fmt.Printf("%s\n", line)
-			b2, err2 := loadBoard([]byte(line))
-			if err2 != nil {
-				b2.PrintCompact()
+		item := func(i int, line string) listResult { return solveListItem(i, line, opts) }
+		if *checkMinimal {
+			item = func(i int, line string) listResult { return checkMinimalItem(i, line, opts) }
+		} else if countSolutions != 0 {
+			item = func(i int, line string) listResult {
+				return countSolutionsItem(i, line, int(countSolutions), *printSolutions, opts)
 			}
-			return fmt.Errorf("puz=%d err=%q", i+1, err)
 		}
-
-		if !board.isSolved() {
-			board.PrintHints()
-			board.PrintCompact()
-			return fmt.Errorf("could not solve Puzzle # %d", i+1)
-		}
-
-		board.Print()
-
-		if showSolveTime {
-			fmt.Printf("puz=%d time=%v\n", i+1, time.Since(start1))
-		}
-
-		line, err = r.ReadString('\n')
-		if err != nil && err != io.EOF {
-			return err
+		if err := runList(os.Stdout, *runFile, *maxPuzzles, *workers, item); err != nil {
+			log.Fatal(err)
 		}
 	}
-	return nil
-}
-
-func runFile(fileName string) {
-	board, err := getBoard(fileName)
-	if err != nil {
-		fmt.Printf("ERROR - %s\n", err)
-		return
-	}
-
-	if err = board.Solve(); err != nil {
-		fmt.Printf("ERROR - %s\n", err)
-		return
-	}
-
-	if !board.isSolved() {
-		board.PrintHints()
-		board.PrintCompact()
-		fmt.Println("could not solve")
-	} else {
-		board.Print()
-	}
 }
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gerred/go-sudoku/sudoku"
+)
+
+type listResult struct {
+	index  int
+	output []byte
+	err    error
+}
+
+// listItemFunc processes the puzzle on line i of a list and returns its
+// buffered output.
+type listItemFunc func(i int, line string) listResult
+
+// runList runs item on the compact puzzles in fileName using a pool of
+// workers. Each puzzle's output is buffered and written to w in input order.
+// A failed puzzle is reported in its place and does not stop the run.
+func runList(w io.Writer, fileName string, maxPuzzles, workers int, item listItemFunc) error {
+	lines, err := readList(fileName, maxPuzzles)
+	if err != nil {
+		return err
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	done := make(chan struct{})
+	jobs := make(chan int)
+	results := make(chan listResult)
+
+	var wg sync.WaitGroup
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- item(i, lines[i])
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+		for i := range lines {
+			select {
+			case jobs <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// write results in input order as they become available
+	pending := make(map[int]listResult)
+	next := 0
+	failed := 0
+	for result := range results {
+		if err != nil {
+			// draining after a write error
+			continue
+		}
+
+		pending[result.index] = result
+		for {
+			cur, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			if _, err = w.Write(cur.output); err != nil {
+				close(done)
+				break
+			}
+			if cur.err != nil {
+				failed++
+			}
+			next++
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d puzzles failed", failed, len(lines))
+	}
+	return nil
+}
+
+func readList(fileName string, maxPuzzles int) ([]string, error) {
+	b, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	r := bufio.NewReader(bytes.NewReader(b))
+	line, err := r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	for i := 0; line != "" && (maxPuzzles == -1 || i < maxPuzzles); i++ {
+		lines = append(lines, strings.TrimRight(line, "\r\n"))
+
+		line, err = r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+func solveListItem(i int, line string, opts outputOptions) listResult {
+	if opts.format == formatJSON {
+		return solveListItemJSON(i, line, opts)
+	}
+
+	buf := &bytes.Buffer{}
+	result := listResult{index: i}
+
+	fmt.Fprintf(buf, "-----------------\nPuzzle # %d:\n", i+1)
+	start := time.Now()
+
+	board, err := sudoku.LoadBoard([]byte(line))
+	if err != nil {
+		if board != nil {
+			board.SetOutput(buf)
+			board.PrintHints()
+		}
+		result.err = fmt.Errorf("puz=%d err=%q", i+1, err)
+	} else {
+		board.SetOutput(buf)
+		board.SetRecordSteps(opts.showSteps)
+		board.SetBackend(opts.backend)
+
+		err = solveBoard(board, opts)
+		board.Trace().Print(buf)
+		if err != nil {
+			fmt.Fprintf(buf, "%s\n", line)
+			result.err = fmt.Errorf("puz=%d err=%q", i+1, err)
+		} else if !board.IsSolved() {
+			board.PrintHints()
+			board.PrintCompact()
+			result.err = fmt.Errorf("could not solve Puzzle # %d", i+1)
+		} else {
+			board.Print()
+		}
+	}
+
+	if result.err != nil {
+		fmt.Fprintf(buf, "ERROR - %s\n", result.err)
+	}
+
+	if opts.showSolveTime {
+		fmt.Fprintf(buf, "puz=%d time=%v\n", i+1, time.Since(start))
+	}
+
+	result.output = buf.Bytes()
+	return result
+}
+
+func solveListItemJSON(i int, line string, opts outputOptions) listResult {
+	result := listResult{index: i}
+	start := time.Now()
+
+	board, err := sudoku.LoadBoard([]byte(line))
+	if err == nil {
+		board.SetRecordSteps(true)
+		board.SetBackend(opts.backend)
+		err = solveBoard(board, opts)
+	} else {
+		board = nil
+	}
+
+	puzzle := newPuzzleJSON(line, board, err, opts, time.Since(start))
+	if puzzle.Error != "" {
+		result.err = fmt.Errorf("puz=%d err=%q", i+1, puzzle.Error)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = writeJSON(buf, puzzle); err != nil {
+		result.err = err
+	}
+	result.output = buf.Bytes()
+	return result
+}
+
+// solveBoard solves b with human techniques or, with -mode brute, by brute
+// force without recording steps.
+func solveBoard(b *sudoku.Board, opts outputOptions) error {
+	if opts.mode == modeBrute {
+		return b.SolveBrute()
+	}
+	b.SetAssumeUnique(opts.assumeUnique)
+	return b.Solve()
+}
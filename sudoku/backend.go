@@ -0,0 +1,110 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Backend is the brute-force solver used once the human techniques are
+// stuck, and by Solutions and HasUniqueSolution.
+type Backend int
+
+const (
+	// BackendSAT uses the DPLL SAT solver, see SolveSAT.
+	BackendSAT Backend = iota
+	// BackendDLX uses Algorithm X with dancing links, see SolveDLX.
+	BackendDLX
+)
+
+var backendNames = []string{"sat", "dlx"}
+
+func (backend Backend) String() string {
+	if backend < 0 || int(backend) >= len(backendNames) {
+		return fmt.Sprintf("Backend(%d)", int(backend))
+	}
+	return backendNames[backend]
+}
+
+// ParseBackend parses a backend name as returned by Backend.String.
+func ParseBackend(s string) (Backend, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range backendNames {
+		if s == name {
+			return Backend(i), nil
+		}
+	}
+	return BackendSAT, fmt.Errorf("unknown backend %q, expected one of %s", s, strings.Join(backendNames, ", "))
+}
+
+// SetBackend sets the brute-force solver, the default is BackendSAT.
+func (b *Board) SetBackend(backend Backend) {
+	b.backend = backend
+}
+
+func (b *Board) getBackendSolver() solver {
+	if b.backend == BackendDLX {
+		return solver{name: "DLX", difficulty: 100, run: b.SolveDLX}
+	}
+	return solver{name: "SAT", difficulty: 100, run: b.SolveSAT}
+}
+
+// HasUniqueSolution returns true if the board has exactly one solution.
+// The board is not modified.
+func (b *Board) HasUniqueSolution() (bool, error) {
+	list, err := b.Solutions(2)
+	if err != nil {
+		return false, err
+	}
+	return len(list) == 1, nil
+}
+
+// Solutions returns up to max distinct solutions of the board in compact
+// form, so a result of length max means there are at least max solutions.
+// An unsolvable board returns an empty list. The board is not modified.
+func (b *Board) Solutions(max int) ([]string, error) {
+	if max < 1 {
+		return nil, fmt.Errorf("max solutions must be at least 1, actual: %d", max)
+	}
+
+	if b.backend == BackendDLX {
+		var list []string
+		for _, sln := range b.getDLX().solve(max) {
+			b2 := &Board{solved: b.solved, blits: b.blits}
+			b2.applyDLX(sln)
+			list = append(list, b2.GetCompact())
+		}
+		return list, nil
+	}
+
+	satSolver, err := NewSAT(b.getSAT(), true, max)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []string
+	seen := make(map[string]interface{})
+	for _, sln := range satSolver.Solve() {
+		b2 := &Board{solved: b.solved, blits: b.blits}
+		b2.applySAT(sln)
+		if !b2.IsSolved() || b2.Validate() != nil {
+			continue
+		}
+
+		compact := b2.GetCompact()
+		if _, ok := seen[compact]; ok {
+			continue
+		}
+		seen[compact] = struct{}{}
+		list = append(list, compact)
+		if len(list) == max {
+			break
+		}
+	}
+	return list, nil
+}
+
+// isBackendSolver returns true for the solver names of the backends, which
+// are not human techniques.
+func isBackendSolver(name string) bool {
+	return name == "SAT" || name == "DLX"
+}
@@ -0,0 +1,385 @@
+package sudoku
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// Board is a 9x9 Sudoku grid holding the solved values and the remaining
+// candidates (hints) of every cell. Use LoadBoard, NewBoardFromCompact or
+// NewBoardFromGrid to create one.
+type Board struct {
+	solved       [81]uint
+	blits        [81]uint
+	loading      bool
+	changed      bool
+	recordSteps  bool
+	difficulty   int
+	solversUsed  []string
+	trace        *Trace
+	out          io.Writer
+	backend      Backend
+	assumeUnique bool
+}
+
+type coords struct {
+	row int
+	col int
+	box int
+	pos int
+}
+
+type inspector func(int, int) error
+type containerOperator func(int, inspector) error
+
+func getCoords(pos int) coords {
+	boxRow := ((pos / 9) / 3)
+	boxCol := ((pos % 9) / 3)
+	box := boxRow*3 + boxCol
+
+	return coords{row: pos / 9, col: pos % 9, box: box, pos: pos}
+}
+
+func (c coords) String() string {
+	return fmt.Sprintf("%c%c", getTextRow(c.row), getTextCol(c.col))
+}
+
+// CellName returns the name used in solve steps for a position 0-80
+// (row*9 + col), rows A-J (skipping I) and columns 1-9, e.g. "J9" for 80.
+func CellName(pos int) string {
+	return getCoords(pos).String()
+}
+
+func getTextRow(row int) int {
+	if row == 8 {
+		return 'J' // the letter "I" is skipped
+	}
+	return 'A' + row
+}
+
+func getTextCol(col int) int {
+	return '1' + col
+}
+
+func (b *Board) operateOnRow(pos int, op inspector) error {
+	startRow := (pos / 9) * 9
+	for r := startRow; r < startRow+9; r++ {
+		if err := op(r, pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Board) operateOnColumn(pos int, op inspector) error {
+	for c := pos % 9; c < 81; c += 9 {
+		if err := op(c, pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Board) operateOnBox(pos int, op inspector) error {
+	startRow := ((pos / 9) / 3) * 3
+	startCol := ((pos % 9) / 3) * 3
+	for r := startRow; r < startRow+3; r++ {
+		for c := startCol; c < startCol+3; c++ {
+			target := r*9 + c
+			if err := op(target, pos); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Board) operateOnRCB(pos int, op inspector) error {
+	if err := b.operateOnRow(pos, op); err != nil {
+		return err
+	}
+	if err := b.operateOnColumn(pos, op); err != nil {
+		return err
+	}
+	if err := b.operateOnBox(pos, op); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *Board) operateOnCommon(pos1 int, pos2 int, op inspector) error {
+	coords1 := getCoords(pos1)
+	coords2 := getCoords(pos2)
+
+	if coords1.row == coords2.row {
+		if err := b.operateOnRow(pos1, op); err != nil {
+			return err
+		}
+	}
+	if coords1.col == coords2.col {
+		if err := b.operateOnColumn(pos1, op); err != nil {
+			return err
+		}
+	}
+	if coords1.box == coords2.box {
+		if err := b.operateOnBox(pos1, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBoard reads a board in the 9-line grid format ("1 _ 3 ...") from rd
+// and returns it in the form accepted by LoadBoard. A single 81-char line
+// in compact form is also accepted.
+func ReadBoard(rd io.Reader) ([]byte, error) {
+	r := bufio.NewReader(rd)
+
+	var line string
+	var err error
+
+	buf := bytes.NewBufferString("")
+
+	for i := 0; i < 9; i++ {
+		if line, err = r.ReadString('\n'); err != nil && err != io.EOF {
+			return nil, err
+		}
+		line = strings.Trim(line, "\r\n")
+
+		// small deviation from spec, accept compact 81-char format
+		if i == 0 && len(line) == 81 {
+			return []byte(line), nil
+		}
+
+		if len(line) != 17 {
+			return nil, fmt.Errorf("line %d: expected: len=17, actual: len=%d. line: %q", i+1, len(line), line)
+		}
+
+		for j, r := range line {
+			if j%2 == 0 {
+				if r != '_' {
+					var v int
+					if v, err = strconv.Atoi(string(r)); err != nil || v == 0 {
+						return nil, fmt.Errorf("line %d, pos %d: expected: digit > 0 or '_', actual: %q. line: %q", i+1, j+1, r, line)
+					}
+				}
+				buf.WriteRune(r)
+			} else {
+				if r != ' ' {
+					return nil, fmt.Errorf("line %d, pos %d: expected: \" \", actual: %q. line: %q", i+1, j+1, r, line)
+				}
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// LoadBoardFile loads a board from a file in either compact or grid form.
+func LoadBoardFile(fileName string) (*Board, error) {
+	b, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadBoard(b)
+}
+
+// LoadBoard creates a board from its compact 81-char form. Whitespace is
+// ignored and '_', '0' and '.' indicate an empty cell. The values are placed
+// and candidates eliminated but no solve techniques are run.
+func LoadBoard(b []byte) (*Board, error) {
+	b = bytes.Replace(b, []byte{'\r'}, []byte{}, -1)
+	b = bytes.Replace(b, []byte{'\n'}, []byte{}, -1)
+	b = bytes.Replace(b, []byte{' '}, []byte{}, -1)
+
+	if len(b) != 81 {
+		return nil, fmt.Errorf("line needs to be 81 chars long. line: %q", string(b))
+	}
+
+	board := &Board{loading: true}
+	for i := 0; i < 81; i++ {
+		board.blits[i] = 0x1FF
+	}
+
+	for i := 0; i < 81; i++ {
+		// allow _ 0 . to indicate an empty cell
+		if b[i] != '_' && b[i] != '0' && b[i] != '.' {
+			if b[i] < '1' || b[i] > '9' {
+				return nil, fmt.Errorf("pos %d: expected: digit or '_', '0', '.', actual: %q", i, b[i])
+			}
+			val := uint(b[i] - 48)
+			if err := board.SolvePosition(i, val); err != nil {
+				return board, err
+			}
+		}
+	}
+
+	board.loading = false
+
+	return board, nil
+}
+
+// NewBoardFromCompact creates a board from the compact 81-char form, for
+// example "1.3..6.8..." or "103006080...".
+func NewBoardFromCompact(s string) (*Board, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != 81 {
+		return nil, fmt.Errorf("compact board needs to be 81 chars long, actual: len=%d", len(s))
+	}
+	return LoadBoard([]byte(s))
+}
+
+// NewBoardFromGrid creates a board from the 9-line grid form, where values
+// are separated by a space and '_' indicates an empty cell.
+func NewBoardFromGrid(s string) (*Board, error) {
+	b, err := ReadBoard(strings.NewReader(s))
+	if err != nil {
+		return nil, err
+	}
+	return LoadBoard(b)
+}
+
+// Clone returns a deep copy of the board.
+func (b *Board) Clone() *Board {
+	clone := *b
+	clone.trace = b.trace.clone()
+	clone.solversUsed = append([]string(nil), b.solversUsed...)
+	return &clone
+}
+
+// Value returns the solved value at the 0-based row and column,
+// or 0 if the cell is not solved.
+func (b *Board) Value(row, col int) int {
+	return int(b.solved[row*9+col])
+}
+
+// Candidates returns the remaining candidates at the 0-based row and column
+// in ascending order. A solved cell returns its value as the only candidate.
+func (b *Board) Candidates(row, col int) []int {
+	var list []int
+	blit := b.blits[row*9+col]
+	for v := 1; v <= 9; v++ {
+		if blit&(1<<uint(v-1)) != 0 {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// SetRecordSteps enables or disables recording of the solve trace.
+func (b *Board) SetRecordSteps(recordSteps bool) {
+	b.recordSteps = recordSteps
+}
+
+// SetShowSteps enables or disables recording of the solve trace.
+//
+// Deprecated: Use SetRecordSteps.
+func (b *Board) SetShowSteps(showSteps bool) {
+	b.SetRecordSteps(showSteps)
+}
+
+// Trace returns the steps recorded while solving, or nil if recording
+// was not enabled with SetRecordSteps.
+func (b *Board) Trace() *Trace {
+	return b.trace
+}
+
+// Difficulty returns the accumulated difficulty of the techniques used
+// to solve the board.
+func (b *Board) Difficulty() int {
+	return b.difficulty
+}
+
+// TechniquesUsed returns the names of the techniques, as listed by
+// Techniques, which made progress while solving, in order of first use.
+func (b *Board) TechniquesUsed() []string {
+	return b.solversUsed
+}
+
+func (b *Board) numSolved() int {
+	num := 0
+	for i := 0; i < 81; i++ {
+		if b.solved[i] != 0 {
+			num++
+		}
+	}
+	return num
+}
+
+// IsSolved returns true if all 81 cells have a value.
+func (b *Board) IsSolved() bool {
+	return b.numSolved() == 81
+}
+
+func (b *Board) getVisibleCells(pos int) []int {
+	var list []int
+	coords := getCoords(pos)
+
+	for i := 0; i < 81; i++ {
+		if i == pos || b.solved[i] != 0 {
+			continue
+		}
+		t := getCoords(i)
+		if t.row == coords.row ||
+			t.col == coords.col ||
+			t.box == coords.box {
+			list = append(list, i)
+		}
+	}
+
+	return list
+}
+
+func (b *Board) getVisibleCellsWithHint(pos int, hint uint) []int {
+	var list []int
+	coords := getCoords(pos)
+
+	for i := 0; i < 81; i++ {
+		if i == pos || b.solved[i] != 0 || b.blits[i]&hint != hint {
+			continue
+		}
+		t := getCoords(i)
+		if t.row == coords.row ||
+			t.col == coords.col ||
+			t.box == coords.box {
+			list = append(list, i)
+		}
+	}
+
+	return list
+}
+
+// getStrongLinks returns the cells which are the only other cell with hint
+// in a row, column or box shared with pos (conjugate pairs).
+func (b *Board) getStrongLinks(pos int, hint uint) []int {
+	var list []int
+	for _, op := range []containerOperator{b.operateOnRow, b.operateOnColumn, b.operateOnBox} {
+		var others []int
+		_ = op(pos, func(target int, source int) error {
+			if target != source && b.solved[target] == 0 && b.blits[target]&hint == hint {
+				others = append(others, target)
+			}
+			return nil
+		})
+		if len(others) == 1 {
+			list = appendUnique(list, others[0])
+		}
+	}
+	return list
+}
+
+// canSee returns true if the two different cells share a row, column or box.
+func canSee(pos1 int, pos2 int) bool {
+	if pos1 == pos2 {
+		return false
+	}
+	c1 := getCoords(pos1)
+	c2 := getCoords(pos2)
+	return c1.row == c2.row || c1.col == c2.col || c1.box == c2.box
+}
@@ -0,0 +1,97 @@
+package sudoku
+
+import (
+	"fmt"
+	"sort"
+)
+
+type updateLog struct {
+	pos      int
+	oldHints uint
+	newHints uint
+}
+
+// AddLog records a step in the board's trace. Consecutive calls with the same
+// technique and description are grouped into one step. In the arguments an
+// int is a cell position and a uint is a hint list; both are formatted
+// for display and collected as the step's cells and digits.
+func (b *Board) AddLog(technique string, log *updateLog, format string, a ...interface{}) {
+	if !b.recordSteps {
+		return
+	}
+
+	if b.trace == nil {
+		b.trace = newTrace(b)
+	}
+
+	var cells []int
+	var digits uint
+	var args []interface{}
+	for _, item := range a {
+		if pos, ok := item.(int); ok {
+			hints := GetBitsString(b.blits[pos])
+			args = append(args, fmt.Sprintf("%s(%s)", getCoords(pos), hints))
+			cells = appendUnique(cells, pos)
+		} else if hints, ok := item.(uint); ok {
+			args = append(args, GetBitsString(hints))
+			digits |= hints
+		} else {
+			args = append(args, item)
+		}
+	}
+
+	description := fmt.Sprintf(format, args...)
+
+	step := b.trace.lastStep()
+	if step == nil || step.Technique != technique || step.Description != description {
+		b.trace.Steps = append(b.trace.Steps, Step{
+			Technique:   technique,
+			Description: description,
+			Cells:       cells,
+			Digits:      bitsToDigits(digits),
+		})
+		step = b.trace.lastStep()
+	}
+
+	if log != nil && log.newHints != 0 {
+		step.Eliminations = append(step.Eliminations, Elimination{
+			Pos:     log.pos,
+			OldMask: log.oldHints,
+			NewMask: log.newHints,
+		})
+	}
+}
+
+// addLogPlacement records a solved cell on the most recent step.
+func (b *Board) addLogPlacement(pos int, val uint) {
+	if !b.recordSteps || b.trace == nil {
+		return
+	}
+
+	step := b.trace.lastStep()
+	if step == nil {
+		return
+	}
+
+	step.Placements = append(step.Placements, Placement{Pos: pos, Value: int(val)})
+	step.Cells = appendUnique(step.Cells, pos)
+	step.Digits = appendUnique(step.Digits, int(val))
+	sort.Ints(step.Digits)
+}
+
+func bitsToDigits(val uint) []int {
+	var list []int
+	for _, bit := range GetBitList(val) {
+		list = append(list, int(GetSingleBitValue(bit)))
+	}
+	return list
+}
+
+func appendUnique(list []int, val int) []int {
+	for _, item := range list {
+		if item == val {
+			return list
+		}
+	}
+	return append(list, val)
+}
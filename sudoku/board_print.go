@@ -1,54 +1,71 @@
-package main
+package sudoku
 
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"os"
 )
 
-func (b *board) Print() {
+// SetOutput sets the destination for the Print methods. The default is
+// os.Stdout. Solve steps are recorded in the Trace instead, see Trace.Print.
+func (b *Board) SetOutput(w io.Writer) {
+	b.out = w
+}
+
+func (b *Board) output() io.Writer {
+	if b.out == nil {
+		return os.Stdout
+	}
+	return b.out
+}
+
+func (b *Board) Print() {
+	w := b.output()
 	for i := 0; i < len(b.solved); i++ {
 		if b.solved[i] == 0 {
-			fmt.Print("_")
+			fmt.Fprint(w, "_")
 		} else {
-			fmt.Printf("%d", b.solved[i])
+			fmt.Fprintf(w, "%d", b.solved[i])
 		}
 		if (i+1)%9 == 0 {
-			fmt.Println()
+			fmt.Fprintln(w)
 		} else {
-			fmt.Print(" ")
+			fmt.Fprint(w, " ")
 		}
 	}
 }
 
-func (b *board) PrintPretty() {
-	fmt.Print("|-------|-------|-------|\n| ")
+func (b *Board) PrintPretty() {
+	w := b.output()
+	fmt.Fprint(w, "|-------|-------|-------|\n| ")
 	for i := 0; i < len(b.solved); i++ {
 		if b.solved[i] == 0 {
-			fmt.Print("_ ")
+			fmt.Fprint(w, "_ ")
 		} else {
-			fmt.Printf("%d ", b.solved[i])
+			fmt.Fprintf(w, "%d ", b.solved[i])
 		}
 		if (i+1)%9 == 0 {
-			fmt.Print("|\n|")
+			fmt.Fprint(w, "|\n|")
 			if (i+1)%27 == 0 {
-				fmt.Print("-------|-------|-------|\n")
+				fmt.Fprint(w, "-------|-------|-------|\n")
 				if i != 80 {
-					fmt.Print("| ")
+					fmt.Fprint(w, "| ")
 				}
 			} else {
-				fmt.Print(" ")
+				fmt.Fprint(w, " ")
 			}
 		} else if (i+1)%3 == 0 {
-			fmt.Print("| ")
+			fmt.Fprint(w, "| ")
 		}
 	}
 }
 
-func (b *board) PrintCompact() {
-	fmt.Println(b.GetCompact())
+func (b *Board) PrintCompact() {
+	fmt.Fprintln(b.output(), b.GetCompact())
 }
 
-func (b *board) GetCompact() string {
+func (b *Board) GetCompact() string {
 	buf := bytes.NewBufferString("")
 	for i := 0; i < 81; i++ {
 		buf.WriteByte('0' + byte(b.solved[i]))
@@ -56,11 +73,11 @@ func (b *board) GetCompact() string {
 	return buf.String()
 }
 
-func (b *board) PrintHints() {
-	fmt.Printf(b.GetTextBoardWithHints())
+func (b *Board) PrintHints() {
+	fmt.Fprint(b.output(), b.GetTextBoardWithHints())
 }
 
-func (b *board) GetTextBoardWithHints() string {
+func (b *Board) GetTextBoardWithHints() string {
 	buf := bytes.NewBufferString("")
 
 	buf.WriteString(fmt.Sprintf("|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|\n"))
@@ -1,11 +1,15 @@
-package main
+package sudoku
 
 import (
 	"fmt"
 	"runtime"
 )
 
-func (b *board) SolveWithSolversList(solvers []solver) error {
+func (b *Board) SolveWithSolversList(solvers []solver) error {
+	if b.recordSteps && b.trace == nil {
+		b.trace = newTrace(b)
+	}
+
 	// first iteration naked single
 	b.loading = true // turn off logging, this run is boring
 
@@ -22,7 +26,9 @@ func (b *board) SolveWithSolversList(solvers []solver) error {
 	return nil
 }
 
-func (b *board) Solve() error {
+// Solve solves the board using human techniques, falling back on the
+// backend set with SetBackend, SAT by default.
+func (b *Board) Solve() error {
 	return b.SolveWithSolversList(b.getSolvers())
 }
 
@@ -32,7 +38,7 @@ type solver struct {
 	difficulty int
 }
 
-func (b *board) getSolvers() []solver {
+func (b *Board) getSolvers() []solver {
 	solvers := []solver{
 		{name: "NAKED SINGLE", difficulty: 0, run: b.SolveNakedSingle},
 		{name: "HIDDEN SINGLE", difficulty: 1, run: b.SolveHiddenSingle},
@@ -46,19 +52,45 @@ func (b *board) getSolvers() []solver {
 		{name: "HIDDEN QUINT", difficulty: 8, run: b.getSolverN(b.SolveHiddenN, 5)},
 		{name: "POINTING PAIR AND TRIPLE REDUCTION", difficulty: 10, run: b.SolvePointingPairAndTripleReduction},
 		{name: "BOX LINE", difficulty: 10, run: b.SolveBoxLine},
+		{name: "SKYSCRAPER", difficulty: 10, run: b.SolveSkyscraper},
+		{name: "2-STRING KITE", difficulty: 10, run: b.SolveTwoStringKite},
+		{name: "TURBOT FISH", difficulty: 10, run: b.SolveTurbotFish},
 		{name: "X-WING", difficulty: 10, run: b.SolveXWing},
 		{name: "SIMPLE-COLORING", difficulty: 10, run: b.SolveSimpleColoring},
 		{name: "Y-WING", difficulty: 10, run: b.SolveYWing},
 		{name: "SWORDFISH", difficulty: 10, run: b.SolveSwordFish},
+		{name: "JELLYFISH", difficulty: 12, run: b.SolveJellyfish},
+		{name: "FINNED X-WING", difficulty: 11, run: b.SolveFinnedXWing},
+		{name: "SASHIMI X-WING", difficulty: 11, run: b.SolveSashimiXWing},
+		{name: "W-WING", difficulty: 11, run: b.SolveWWing},
+		{name: "REMOTE PAIRS", difficulty: 11, run: b.SolveRemotePairs},
+		{name: "XYZ-WING", difficulty: 12, run: b.SolveXYZWing},
+		{name: "FINNED SWORDFISH", difficulty: 12, run: b.SolveFinnedSwordFish},
+		{name: "SASHIMI SWORDFISH", difficulty: 12, run: b.SolveSashimiSwordFish},
+		{name: "FINNED JELLYFISH", difficulty: 13, run: b.SolveFinnedJellyfish},
+		{name: "SASHIMI JELLYFISH", difficulty: 13, run: b.SolveSashimiJellyfish},
+		{name: "WXYZ-WING", difficulty: 14, run: b.SolveWXYZWing},
 		{name: "XY-CHAIN", difficulty: 10, run: b.SolveXYChain},
 		{name: "EMPTY RECTANGLES", difficulty: 10, run: b.SolveEmptyRectangles},
-		{name: "SAT", difficulty: 100, run: b.SolveSAT},
+		{name: "X-CYCLES", difficulty: 10, run: b.SolveXCycles},
+		{name: "MULTI-COLORING", difficulty: 12, run: b.SolveMultiColoring},
+		{name: "3D MEDUSA", difficulty: 14, run: b.SolveMedusa},
+		{name: "UNIQUE RECTANGLE", difficulty: 10, run: b.SolveUniqueRectangles},
+		{name: "HIDDEN UNIQUE RECTANGLE", difficulty: 10, run: b.SolveHiddenUniqueRectangles},
+		{name: "AIC", difficulty: 15, run: b.SolveAIC},
+		{name: "ALS-XZ", difficulty: 16, run: b.SolveALSXZ},
+		{name: "ALS-XY-WING", difficulty: 17, run: b.SolveALSXYWing},
+		{name: "DEATH BLOSSOM", difficulty: 18, run: b.SolveDeathBlossom},
+		{name: "CELL FORCING CHAINS", difficulty: 20, run: b.SolveCellForcingChains},
+		{name: "UNIT FORCING CHAINS", difficulty: 20, run: b.SolveUnitForcingChains},
+		{name: "DIGIT FORCING CHAINS", difficulty: 22, run: b.SolveDigitForcingChains},
+		b.getBackendSolver(),
 	}
 
 	return solvers
 }
 
-func (b *board) getSolverN(solver func(int) error, n int) func() error {
+func (b *Board) getSolverN(solver func(int) error, n int) func() error {
 	return func() error {
 		if err := solver(n); err != nil {
 			return err
@@ -67,20 +99,22 @@ func (b *board) getSolverN(solver func(int) error, n int) func() error {
 	}
 }
 
-func (b *board) runSolvers(solvers []solver) error {
+func (b *Board) runSolvers(solvers []solver) error {
 mainLoop:
 	for {
 		b.changed = false
 		for _, solver := range solvers {
 			if err := solver.run(); err != nil {
-				return NewErrUnsolvable(err.Error())
+				return NewErrUnsolvable("%s", err)
 			}
-			if b.isSolved() {
+			if b.IsSolved() {
 				b.updateDifficulty(solver.difficulty)
+				b.updateSolversUsed(solver.name)
 				return nil
 			}
 			if b.changed {
 				b.updateDifficulty(solver.difficulty)
+				b.updateSolversUsed(solver.name)
 				continue mainLoop
 			}
 		}
@@ -103,12 +137,12 @@ func getCandidates(n int, store map[int]map[uint]int) map[int][]uint {
 	return list
 }
 
-func (b *board) SolvePositionNoValidate(pos int, val uint) {
+func (b *Board) SolvePositionNoValidate(pos int, val uint) {
 	b.solved[pos] = val
 	b.blits[pos] = 1 << (val - 1)
 }
 
-func (b *board) SolvePosition(pos int, val uint) error {
+func (b *Board) SolvePosition(pos int, val uint) error {
 	mask := uint(^(1 << (val - 1)))
 	removeCandidates := func(target int, source int) error {
 		if _, opErr := b.updateCandidates(target, mask); opErr != nil {
@@ -121,11 +155,10 @@ func (b *board) SolvePosition(pos int, val uint) error {
 		return NewErrUnsolvable("%#v val:%d - %s", getCoords(pos), val, err)
 	}
 
-	logLastBoardWithHints = b.GetTextBoardWithHints()
 	return nil
 }
 
-func (b *board) SolvePositionWithLog(technique, logFormat string, pos int, val uint) error {
+func (b *Board) SolvePositionWithLog(technique, logFormat string, pos int, val uint) error {
 	mask := uint(^(1 << (val - 1)))
 
 	logFormat += fmt.Sprintf(" solved: %d/81", b.numSolved()+1)
@@ -143,6 +176,7 @@ func (b *board) SolvePositionWithLog(technique, logFormat string, pos int, val u
 
 	// writes header
 	b.AddLog(technique, nil, logFormat)
+	b.addLogPlacement(pos, val)
 
 	if err := b.solvePositionWithRemover(pos, val, removeCandidates); err != nil {
 		return NewErrUnsolvable("%#v val:%d - %s", getCoords(pos), val, err)
@@ -151,7 +185,7 @@ func (b *board) SolvePositionWithLog(technique, logFormat string, pos int, val u
 	return nil
 }
 
-func (b *board) solvePositionWithRemover(pos int, val uint, candidateRemover inspector) error {
+func (b *Board) solvePositionWithRemover(pos int, val uint, candidateRemover inspector) error {
 	if b.solved[pos] != 0 && (!b.loading || b.solved[pos] != val) {
 		return NewErrUnsolvable("pos %d has value %d, tried to set with %d", pos, b.solved[pos], val)
 	}
@@ -169,7 +203,7 @@ func (b *board) solvePositionWithRemover(pos int, val uint, candidateRemover ins
 	return nil
 }
 
-func (b *board) updateCandidates(target int, mask uint) (*updateLog, error) {
+func (b *Board) updateCandidates(target int, mask uint) (*updateLog, error) {
 	if b.solved[target] != 0 {
 		return nil, nil
 	}
@@ -192,6 +226,25 @@ func (b *board) updateCandidates(target int, mask uint) (*updateLog, error) {
 	return nil, nil
 }
 
-func (b *board) updateDifficulty(difficulty int) {
+func (b *Board) updateDifficulty(difficulty int) {
 	b.difficulty += difficulty
 }
+
+func (b *Board) updateSolversUsed(name string) {
+	for _, used := range b.solversUsed {
+		if used == name {
+			return
+		}
+	}
+	b.solversUsed = append(b.solversUsed, name)
+}
+
+// Techniques returns the names of the solve techniques in the order
+// they are attempted.
+func Techniques() []string {
+	var list []string
+	for _, solver := range (&Board{}).getSolvers() {
+		list = append(list, solver.name)
+	}
+	return list
+}
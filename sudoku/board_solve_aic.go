@@ -0,0 +1,367 @@
+package sudoku
+
+import (
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// Alternating Inference Chains link candidates, strongly when at least one
+// of them is true and weakly when at most one is, alternating strong and
+// weak links. A chain starting and ending with a strong link means one of
+// its ends is true, so candidates which are weakly linked to both ends can
+// be removed.
+//
+// Links are taken in cells (any two candidates of a cell are weakly
+// linked, and strongly when they're the only two) and houses (any two
+// nodes with the same hint are weakly linked, and strongly when they're
+// the only two). A node is a candidate, or a group of the cells with a hint
+// in the intersection of a box and a row or column.
+//
+// A chain which loops back to its start is a discontinuous nice loop: with
+// strong links at both ends the start is true, with weak links it's false.
+//
+// http://www.sudokuwiki.org/Alternating_Inference_Chains
+// http://www.sudokuwiki.org/Grouped_X_Cycles
+
+type aicNode struct {
+	cells []int
+	hint  uint
+}
+
+// aicGraph holds the nodes and links of the candidates of a board.
+// weakSet[i] has the bits of the single cell nodes weakly linked to node i.
+type aicGraph struct {
+	nodes   []aicNode
+	strong  [][]int
+	weak    [][]int
+	weakSet [][]uint64
+	single  [81][9]int
+}
+
+func (b *Board) getAICGraph() *aicGraph {
+	g := &aicGraph{}
+	for pos := 0; pos < 81; pos++ {
+		for i := 0; i < 9; i++ {
+			g.single[pos][i] = -1
+		}
+		if b.solved[pos] != 0 {
+			continue
+		}
+		for _, hint := range GetBitList(b.blits[pos]) {
+			g.single[pos][GetSingleBitValue(hint)-1] = len(g.nodes)
+			g.nodes = append(g.nodes, aicNode{cells: []int{pos}, hint: hint})
+		}
+	}
+	singles := len(g.nodes)
+
+	// groups in the intersections of boxes with rows and columns
+	for box := 0; box < 9; box++ {
+		start := (box/3)*27 + (box%3)*3
+		var segments [][]int
+		for i := 0; i < 3; i++ {
+			segments = append(segments, []int{start + i*9, start + i*9 + 1, start + i*9 + 2})
+			segments = append(segments, []int{start + i, start + i + 9, start + i + 18})
+		}
+		for _, segment := range segments {
+			for hint := uint(1); hint <= 0x100; hint <<= 1 {
+				var cells []int
+				for _, pos := range segment {
+					if b.solved[pos] == 0 && b.blits[pos]&hint != 0 {
+						cells = append(cells, pos)
+					}
+				}
+				if len(cells) >= 2 {
+					g.nodes = append(g.nodes, aicNode{cells: cells, hint: hint})
+				}
+			}
+		}
+	}
+
+	g.strong = make([][]int, len(g.nodes))
+	g.weak = make([][]int, len(g.nodes))
+	links := make(map[[2]int]bool)
+	addLink := func(i int, j int, strong bool) {
+		if i > j {
+			i, j = j, i
+		}
+		key := [2]int{i, j}
+		if isStrong, ok := links[key]; ok && (isStrong || !strong) {
+			return
+		}
+		links[key] = strong
+	}
+
+	// links in cells
+	for pos := 0; pos < 81; pos++ {
+		var list []int
+		for _, i := range g.single[pos] {
+			if i != -1 {
+				list = append(list, i)
+			}
+		}
+		for i := 0; i < len(list); i++ {
+			for j := i + 1; j < len(list); j++ {
+				addLink(list[i], list[j], len(list) == 2)
+			}
+		}
+	}
+
+	// links in houses
+	for _, unit := range bruteUnits {
+		for hint := uint(1); hint <= 0x100; hint <<= 1 {
+			var cells []int
+			for _, pos := range unit {
+				if b.solved[pos] == 0 && b.blits[pos]&hint != 0 {
+					cells = append(cells, pos)
+				}
+			}
+			if len(cells) < 2 {
+				continue
+			}
+
+			var inUnit [81]bool
+			for _, pos := range cells {
+				inUnit[pos] = true
+			}
+
+			var list []int
+		nodeLoop:
+			for i, node := range g.nodes {
+				if node.hint != hint {
+					continue
+				}
+				for _, pos := range node.cells {
+					if !inUnit[pos] {
+						continue nodeLoop
+					}
+				}
+				list = append(list, i)
+			}
+
+			for i := 0; i < len(list); i++ {
+				for j := i + 1; j < len(list); j++ {
+					cells1, cells2 := g.nodes[list[i]].cells, g.nodes[list[j]].cells
+					if len(intersect(cells1, cells2)) != 0 {
+						continue
+					}
+					addLink(list[i], list[j], len(cells1)+len(cells2) == len(cells))
+				}
+			}
+		}
+	}
+
+	words := (singles + 63) / 64
+	g.weakSet = make([][]uint64, len(g.nodes))
+	for i := range g.weakSet {
+		g.weakSet[i] = make([]uint64, words)
+	}
+	for key, strong := range links {
+		i, j := key[0], key[1]
+		if strong {
+			g.strong[i] = append(g.strong[i], j)
+			g.strong[j] = append(g.strong[j], i)
+		}
+		g.weak[i] = append(g.weak[i], j)
+		g.weak[j] = append(g.weak[j], i)
+		if j < singles {
+			g.weakSet[i][j/64] |= 1 << uint(j%64)
+		}
+		if i < singles {
+			g.weakSet[j][i/64] |= 1 << uint(i%64)
+		}
+	}
+
+	// visit links in order so the solve path doesn't depend on map order
+	for i := range g.nodes {
+		sort.Ints(g.strong[i])
+		sort.Ints(g.weak[i])
+	}
+
+	return g
+}
+
+// aicState is a node being true (on) or false.
+type aicState struct {
+	node int
+	on   bool
+}
+
+func (s aicState) index() int {
+	if s.on {
+		return s.node*2 + 1
+	}
+	return s.node * 2
+}
+
+// follow walks the implications of start, from a true node along weak links
+// to false nodes and from a false node along strong links to true nodes,
+// calling visit with the shortest chain to every state reached until it
+// returns true.
+func (g *aicGraph) follow(start aicState, visit func(chain []aicState) bool) {
+	parent := make([]int, len(g.nodes)*2)
+	for i := range parent {
+		parent[i] = -1
+	}
+	parent[start.index()] = start.index()
+
+	getChain := func(state aicState) []aicState {
+		var chain []aicState
+		for i := state.index(); ; i = parent[i] {
+			chain = append([]aicState{{node: i / 2, on: i%2 == 1}}, chain...)
+			if parent[i] == i {
+				return chain
+			}
+		}
+	}
+
+	queue := []aicState{start}
+	for len(queue) != 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		links := g.strong[state.node]
+		if state.on {
+			links = g.weak[state.node]
+		}
+		for _, node := range links {
+			next := aicState{node: node, on: !state.on}
+			if parent[next.index()] != -1 {
+				continue
+			}
+			parent[next.index()] = state.index()
+			if visit(getChain(next)) {
+				return
+			}
+			if node != start.node {
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// SolveAIC looks for discontinuous nice loops and chains with eliminations
+// at their ends, from the shortest.
+func (b *Board) SolveAIC() error {
+	const technique = "AIC"
+
+	g := b.getAICGraph()
+
+	var err error
+	for i := range g.nodes {
+		node := g.nodes[i]
+
+		// a true start leading to a false start can't be
+		g.follow(aicState{node: i, on: true}, func(chain []aicState) bool {
+			last := chain[len(chain)-1]
+			if last.node != i {
+				return false
+			}
+			format, args := g.chainLog(chain)
+			for _, pos := range node.cells {
+				var logEntry *updateLog
+				if logEntry, err = b.updateCandidates(pos, ^node.hint); err != nil {
+					return true
+				}
+				if logEntry != nil {
+					b.AddLog(technique, logEntry, "discontinuous loop "+format+", %v can't be %v", append(args, pos, node.hint)...)
+				}
+			}
+			return true
+		})
+		if err != nil || b.changed {
+			return err
+		}
+
+		g.follow(aicState{node: i, on: false}, func(chain []aicState) bool {
+			last := chain[len(chain)-1]
+			if !last.on {
+				return false
+			}
+
+			// a false start leading to a true start is true
+			if last.node == i {
+				if len(node.cells) != 1 {
+					return false
+				}
+				pos := node.cells[0]
+				format, args := g.chainLog(chain)
+				var logEntry *updateLog
+				if logEntry, err = b.updateCandidates(pos, node.hint); err != nil {
+					return true
+				}
+				if logEntry != nil {
+					b.AddLog(technique, logEntry, "discontinuous loop "+format+", %v must be %v", append(args, pos, node.hint)...)
+				}
+				return true
+			}
+
+			if len(chain) < 4 {
+				// a single strong link is left to simpler techniques
+				return false
+			}
+
+			targets := g.getCommonWeakLinks(i, last.node)
+			if len(targets) == 0 {
+				return false
+			}
+
+			format, args := g.chainLog(chain)
+			for _, target := range targets {
+				t := g.nodes[target]
+				var logEntry *updateLog
+				if logEntry, err = b.updateCandidates(t.cells[0], ^t.hint); err != nil {
+					return true
+				}
+				if logEntry != nil {
+					b.AddLog(technique, logEntry, format, args...)
+				}
+			}
+			return b.changed
+		})
+		if err != nil || b.changed {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getCommonWeakLinks returns the single cell nodes weakly linked to both
+// node1 and node2.
+func (g *aicGraph) getCommonWeakLinks(node1 int, node2 int) []int {
+	var list []int
+	set1, set2 := g.weakSet[node1], g.weakSet[node2]
+	for w := range set1 {
+		for common := set1[w] & set2[w]; common != 0; common &= common - 1 {
+			i := w*64 + bits.TrailingZeros64(common)
+			if i != node1 && i != node2 {
+				list = append(list, i)
+			}
+		}
+	}
+	return list
+}
+
+// chainLog returns the log format and arguments of a chain, "=" marking a
+// strong link and "-" a weak one, each node shown as "(hint)cells".
+func (g *aicGraph) chainLog(chain []aicState) (string, []interface{}) {
+	var format []string
+	var args []interface{}
+	for i, state := range chain {
+		if i != 0 {
+			if state.on {
+				format = append(format, "=")
+			} else {
+				format = append(format, "-")
+			}
+		}
+		node := g.nodes[state.node]
+		format = append(format, "(%v)"+strings.TrimSuffix(strings.Repeat("%v+", len(node.cells)), "+"))
+		args = append(args, node.hint)
+		for _, pos := range node.cells {
+			args = append(args, pos)
+		}
+	}
+	return strings.Join(format, ""), args
+}
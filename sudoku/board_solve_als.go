@@ -0,0 +1,363 @@
+package sudoku
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// An Almost Locked Set is N cells of a house with N+1 candidates between
+// them, a bivalue cell being the smallest. Removing any one candidate
+// locks the others into the cells. Two ALS share a restricted common
+// candidate (RCC) when all the cells with it in both can see each other, so
+// it's in one of the ALS at most and the other is locked.
+//
+// http://www.sudokuwiki.org/Almost_Locked_Sets
+// http://www.sudokuwiki.org/Death_Blossom
+
+// cellSet is a bit set of the 81 cells.
+type cellSet [2]uint64
+
+func (s *cellSet) add(pos int) {
+	s[pos/64] |= 1 << uint(pos%64)
+}
+
+func (s cellSet) and(s2 cellSet) cellSet {
+	return cellSet{s[0] & s2[0], s[1] & s2[1]}
+}
+
+func (s cellSet) or(s2 cellSet) cellSet {
+	return cellSet{s[0] | s2[0], s[1] | s2[1]}
+}
+
+func (s cellSet) andNot(s2 cellSet) cellSet {
+	return cellSet{s[0] &^ s2[0], s[1] &^ s2[1]}
+}
+
+func (s cellSet) isEmpty() bool {
+	return s[0] == 0 && s[1] == 0
+}
+
+func (s cellSet) list() []int {
+	var list []int
+	for w, word := range s {
+		for ; word != 0; word &= word - 1 {
+			list = append(list, w*64+bits.TrailingZeros64(word))
+		}
+	}
+	return list
+}
+
+var cellPeers [81]cellSet
+
+func init() {
+	// brutePeers isn't set yet, init runs in file order
+	for pos := 0; pos < 81; pos++ {
+		for peer := 0; peer < 81; peer++ {
+			if canSee(pos, peer) {
+				cellPeers[pos].add(peer)
+			}
+		}
+	}
+}
+
+type als struct {
+	cells cellSet
+	hints uint
+	// per hint, the cells with it and the cells seeing all of them
+	hintCells [9]cellSet
+	hintPeers [9]cellSet
+}
+
+// getALS returns the almost locked sets of up to maxALSCells cells in the
+// houses of the board, each set once.
+func (b *Board) getALS() []*als {
+	const maxALSCells = 5
+
+	var list []*als
+	seen := make(map[cellSet]bool)
+	for _, unit := range bruteUnits {
+		var cells []int
+		for _, pos := range unit {
+			if b.solved[pos] == 0 {
+				cells = append(cells, pos)
+			}
+		}
+
+		for n := 1; n <= maxALSCells && n < len(cells); n++ {
+			for _, subset := range getPermutations(n, cells, []int{}) {
+				var hints uint
+				var set cellSet
+				for _, pos := range subset {
+					hints |= b.blits[pos]
+					set.add(pos)
+				}
+				if GetNumberOfSetBits(hints) != uint(n+1) || seen[set] {
+					continue
+				}
+				seen[set] = true
+
+				a := &als{cells: set, hints: hints}
+				for _, hint := range GetBitList(hints) {
+					i := GetSingleBitValue(hint) - 1
+					a.hintPeers[i] = cellSet{^uint64(0), ^uint64(0)}
+					for _, pos := range subset {
+						if b.blits[pos]&hint != 0 {
+							a.hintCells[i].add(pos)
+							a.hintPeers[i] = a.hintPeers[i].and(cellPeers[pos])
+						}
+					}
+				}
+				list = append(list, a)
+			}
+		}
+	}
+	return list
+}
+
+// getRCC returns the restricted common candidates of two ALS which don't
+// overlap.
+func getRCC(a1 *als, a2 *als) uint {
+	if !a1.cells.and(a2.cells).isEmpty() {
+		return 0
+	}
+
+	var rcc uint
+	for _, hint := range GetBitList(a1.hints & a2.hints) {
+		i := GetSingleBitValue(hint) - 1
+		if a2.hintCells[i].andNot(a1.hintPeers[i]).isEmpty() {
+			rcc |= hint
+		}
+	}
+	return rcc
+}
+
+// getHintCells returns the unsolved cells with hint.
+func (b *Board) getHintCells(hint uint) cellSet {
+	var set cellSet
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] == 0 && b.blits[pos]&hint != 0 {
+			set.add(pos)
+		}
+	}
+	return set
+}
+
+// alsRemove removes hint from the cells with it which see all the cells
+// with it in the ALS list, outside of them.
+func (b *Board) alsRemove(technique string, format string, args []interface{}, hint uint, list ...*als) error {
+	i := GetSingleBitValue(hint) - 1
+	targets := b.getHintCells(hint)
+	for _, a := range list {
+		targets = targets.and(a.hintPeers[i]).andNot(a.cells)
+	}
+
+	for _, target := range targets.list() {
+		logEntry, err := b.updateCandidates(target, ^hint)
+		if err != nil {
+			return err
+		}
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, format, args...)
+		}
+	}
+	return nil
+}
+
+// alsLog returns the log format and arguments of an ALS, e.g. "{A1 A2}".
+func alsLog(a *als) (string, []interface{}) {
+	var args []interface{}
+	for _, pos := range a.cells.list() {
+		args = append(args, pos)
+	}
+	return "{" + strings.TrimSuffix(strings.Repeat("%v ", len(args)), " ") + "}", args
+}
+
+// SolveALSXZ: ALS A and B share an RCC X, one of them must have every other
+// common candidate Z, so Z can be removed from the cells seeing all the Z in
+// A and B. When doubly linked by two RCCs both ALS are locked sets: each
+// candidate can be removed from the cells seeing all of it in either ALS,
+// and each RCC from the cells seeing all of it in both.
+func (b *Board) SolveALSXZ() error {
+	const technique = "ALS-XZ"
+
+	list := b.getALS()
+	for i, a1 := range list {
+		for _, a2 := range list[i+1:] {
+			rcc := getRCC(a1, a2)
+			if rcc == 0 {
+				continue
+			}
+
+			format1, args1 := alsLog(a1)
+			format2, args2 := alsLog(a2)
+			var args []interface{}
+			args = append(args, args1...)
+			args = append(args, args2...)
+			args = append(args, rcc)
+
+			if HasSingleBit(rcc) {
+				format := "singly linked ALS " + format1 + " and " + format2 + " rcc %v z %v"
+				for _, z := range GetBitList(a1.hints & a2.hints &^ rcc) {
+					if err := b.alsRemove(technique, format, append(args, z), z, a1, a2); err != nil {
+						return err
+					}
+				}
+			} else {
+				format := "doubly linked ALS " + format1 + " and " + format2 + " rcc %v"
+				for _, x := range GetBitList(rcc) {
+					if err := b.alsRemove(technique, format, args, x, a1, a2); err != nil {
+						return err
+					}
+				}
+				for _, a := range []*als{a1, a2} {
+					for _, z := range GetBitList(a.hints &^ rcc) {
+						if err := b.alsRemove(technique, format, args, z, a); err != nil {
+							return err
+						}
+					}
+				}
+			}
+
+			if b.changed {
+				// let simpler techniques take over
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// SolveALSXYWing: ALS A and B each share an RCC with a pivot ALS C, X and Y.
+// If A doesn't have X, C has it, so C can't have Y and B has it. So one of A
+// and B must have every common candidate Z other than X and Y, and Z can be
+// removed from the cells seeing all the Z in A and B.
+func (b *Board) SolveALSXYWing() error {
+	const technique = "ALS-XY-WING"
+
+	list := b.getALS()
+	for _, pivot := range list {
+		var wings []*als
+		var rccs []uint
+		for _, a := range list {
+			if rcc := getRCC(pivot, a); rcc != 0 {
+				wings = append(wings, a)
+				rccs = append(rccs, rcc)
+			}
+		}
+
+		for i, a1 := range wings {
+			for j := i + 1; j < len(wings); j++ {
+				a2 := wings[j]
+				if !a1.cells.and(a2.cells).isEmpty() {
+					continue
+				}
+
+				for _, x := range GetBitList(rccs[i]) {
+					for _, y := range GetBitList(rccs[j] &^ x) {
+						zs := a1.hints & a2.hints &^ (x | y)
+						if zs == 0 {
+							continue
+						}
+
+						format1, args1 := alsLog(a1)
+						format2, args2 := alsLog(a2)
+						format3, args3 := alsLog(pivot)
+						format := "ALS " + format1 + " and " + format2 + " pivot " + format3 + " rcc %v %v z %v"
+						var args []interface{}
+						args = append(args, args1...)
+						args = append(args, args2...)
+						args = append(args, args3...)
+						args = append(args, x, y)
+
+						for _, z := range GetBitList(zs) {
+							if err := b.alsRemove(technique, format, append(args, z), z, a1, a2); err != nil {
+								return err
+							}
+						}
+						if b.changed {
+							// let simpler techniques take over
+							return nil
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SolveDeathBlossom: a stem cell has a petal ALS for each of its candidates,
+// the candidate's cells in the petal all seeing the stem. Whichever the
+// stem is, its petal is locked without it, so one of the petals must have
+// every candidate Z they share, and Z can be removed from the cells seeing
+// all the Z in the petals.
+func (b *Board) SolveDeathBlossom() error {
+	const technique = "DEATH BLOSSOM"
+
+	list := b.getALS()
+	for stem := 0; stem < 81; stem++ {
+		n := GetNumberOfSetBits(b.blits[stem])
+		if b.solved[stem] != 0 || n < 2 || n > 3 {
+			continue
+		}
+
+		// petals for each candidate of the stem
+		stemHints := GetBitList(b.blits[stem])
+		petals := make([][]*als, len(stemHints))
+		for k, hint := range stemHints {
+			i := GetSingleBitValue(hint) - 1
+			for _, a := range list {
+				if a.hints&hint != 0 && a.hintCells[i].andNot(cellPeers[stem]).isEmpty() {
+					petals[k] = append(petals[k], a)
+				}
+			}
+		}
+
+		var chosen []*als
+		var search func(k int, zs uint, cells cellSet) error
+		search = func(k int, zs uint, cells cellSet) error {
+			if k == len(stemHints) {
+				var args []interface{}
+				format := "stem %v petals"
+				args = append(args, stem)
+				for _, a := range chosen {
+					f, a := alsLog(a)
+					format += " " + f
+					args = append(args, a...)
+				}
+				format += " z %v"
+				for _, z := range GetBitList(zs) {
+					if err := b.alsRemove(technique, format, append(args, z), z, chosen...); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			for _, petal := range petals[k] {
+				next := zs & petal.hints
+				if next == 0 || !cells.and(petal.cells).isEmpty() {
+					continue
+				}
+				chosen = append(chosen, petal)
+				if err := search(k+1, next, cells.or(petal.cells)); err != nil {
+					return err
+				}
+				chosen = chosen[:len(chosen)-1]
+				if b.changed {
+					return nil
+				}
+			}
+			return nil
+		}
+
+		if err := search(0, 0x1FF&^b.blits[stem], cellSet{}); err != nil {
+			return err
+		}
+		if b.changed {
+			// let simpler techniques take over
+			return nil
+		}
+	}
+	return nil
+}
@@ -1,6 +1,6 @@
-package main
+package sudoku
 
-func (b *board) SolveBoxLine() error {
+func (b *Board) SolveBoxLine() error {
 	// Two cells in a BOX that share a hint which isn't anywhere else on
 	// the ROW or COLUMN they share can be removed as hints from other cells
 	// in the same BOX.
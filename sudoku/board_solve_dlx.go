@@ -0,0 +1,61 @@
+package sudoku
+
+import "sort"
+
+// SolveDLX solves the board as an exact cover problem using Algorithm X
+// with dancing links. It is a drop-in replacement for SolveSAT.
+func (b *Board) SolveDLX() error {
+	slns := b.getDLX().solve(1)
+	if len(slns) == 0 {
+		return NewErrUnsolvable("could not solve with DLX")
+	}
+
+	placements := b.applyDLX(slns[0])
+	if err := b.Validate(); err != nil {
+		return err
+	}
+
+	b.AddLog("DLX", nil, "Solved with DLX")
+	sort.Ints(placements)
+	for _, pos := range placements {
+		b.addLogPlacement(pos, b.solved[pos])
+	}
+
+	return nil
+}
+
+// getDLX returns the exact cover problem of the board. Each remaining
+// candidate is a row with the id pos*9 + value-1, covering one of the 81
+// cell columns and one of the 81 row, column and box digit columns.
+func (b *Board) getDLX() *dlx {
+	d := newDLX(4 * 81)
+	for pos := 0; pos < 81; pos++ {
+		coords := getCoords(pos)
+		for v := 0; v < 9; v++ {
+			if b.blits[pos]&(1<<uint(v)) == 0 {
+				continue
+			}
+			d.addRow(pos*9+v, []int{
+				pos,
+				81 + coords.row*9 + v,
+				162 + coords.col*9 + v,
+				243 + coords.box*9 + v,
+			})
+		}
+	}
+	return d
+}
+
+// applyDLX places the values of a DLX solution and returns the positions
+// which were unsolved.
+func (b *Board) applyDLX(sln []int) []int {
+	var placements []int
+	for _, id := range sln {
+		pos := id / 9
+		if b.solved[pos] == 0 {
+			b.SolvePositionNoValidate(pos, uint(id%9+1))
+			placements = append(placements, pos)
+		}
+	}
+	return placements
+}
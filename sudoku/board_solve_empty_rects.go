@@ -1,4 +1,4 @@
-package main
+package sudoku
 
 import "fmt"
 
@@ -27,7 +27,7 @@ func init() {
 	}
 }
 
-func (b *board) getIntersection(pos1 int, pos2 int, excludePos int) (int, error) {
+func (b *Board) getIntersection(pos1 int, pos2 int, excludePos int) (int, error) {
 	storePos := func(list *[]int) inspector {
 		return func(target int, source int) error {
 			if target == source || target == excludePos {
@@ -61,7 +61,7 @@ func (b *board) getIntersection(pos1 int, pos2 int, excludePos int) (int, error)
 	return intersection[0], nil
 }
 
-func (b *board) SolveEmptyRectangles() error {
+func (b *Board) SolveEmptyRectangles() error {
 	// http://www.sudokuwiki.org/Empty_Rectangles
 
 	const technique = "EMPTY RECTANGLES"
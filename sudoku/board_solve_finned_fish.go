@@ -0,0 +1,192 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A finned fish is a basic fish whose base lines also have the hint in a
+// few cells outside the cover lines, the fins, all in the same box. Either
+// a fin has the hint, or the fish holds without them. The hint can be
+// removed from the cells of the cover lines outside the base lines which
+// are in the fins' box, as they're eliminated in both cases. A sashimi
+// fish is a finned fish which would be degenerate without its fins, a base
+// line having a single cell left in the cover lines.
+//
+// http://www.sudokuwiki.org/Finned_X_Wing
+// http://www.sudokuwiki.org/Finned_Swordfish
+// http://www.sudokuwiki.org/Sashimi_Fish
+
+func (b *Board) SolveFinnedXWing() error {
+	return b.solveFinnedFish("FINNED X-WING", 2, false)
+}
+
+func (b *Board) SolveSashimiXWing() error {
+	return b.solveFinnedFish("SASHIMI X-WING", 2, true)
+}
+
+func (b *Board) SolveFinnedSwordFish() error {
+	return b.solveFinnedFish("FINNED SWORDFISH", 3, false)
+}
+
+func (b *Board) SolveSashimiSwordFish() error {
+	return b.solveFinnedFish("SASHIMI SWORDFISH", 3, true)
+}
+
+func (b *Board) SolveFinnedJellyfish() error {
+	return b.solveFinnedFish("FINNED JELLYFISH", 4, false)
+}
+
+func (b *Board) SolveSashimiJellyfish() error {
+	return b.solveFinnedFish("SASHIMI JELLYFISH", 4, true)
+}
+
+// solveFinnedFish looks for finned, or sashimi, fish of the given size in
+// rows then columns.
+func (b *Board) solveFinnedFish(technique string, size int, sashimi bool) error {
+	for _, dim := range b.getFishOrientations() {
+		for hint := uint(1); hint <= 0x100; hint <<= 1 {
+			cells, covers := b.getFishLines(dim, hint)
+
+			var lines []int
+			for line := 0; line < 9; line++ {
+				if len(cells[line]) >= 2 {
+					lines = append(lines, line)
+				}
+			}
+
+			for _, baseLines := range getPermutations(size, lines, []int{}) {
+				var union uint
+				for _, line := range baseLines {
+					union |= covers[line]
+				}
+
+				// the fins' cover lines are in a single box, so three at most
+				n := int(GetNumberOfSetBits(union))
+				if n <= size || n > size+3 {
+					continue
+				}
+
+				for _, coverLines := range getPermutations(size, getBitIndexes(union), []int{}) {
+					var cover uint
+					for _, line := range coverLines {
+						cover |= 1 << uint(line)
+					}
+
+					if err := b.finnedFishApply(technique, dim, hint, sashimi, baseLines, cells, cover); err != nil {
+						return err
+					}
+					if b.changed {
+						// let simpler techniques take over
+						return nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// finnedFishApply checks the base lines make a finned or sashimi fish with
+// the cover lines, and removes hint from the cells of the cover lines which
+// see all the fins.
+func (b *Board) finnedFishApply(technique string, dim fishOrientation, hint uint, sashimi bool, baseLines []int, cells [9][]int, cover uint) error {
+	var fins []int
+	isSashimi := false
+	for _, line := range baseLines {
+		inCover := 0
+		for _, pos := range cells[line] {
+			if cover&(1<<uint(dim.coverLine(pos))) != 0 {
+				inCover++
+			} else {
+				fins = append(fins, pos)
+			}
+		}
+		if inCover < 2 {
+			isSashimi = true
+		}
+	}
+
+	if isSashimi != sashimi {
+		return nil
+	}
+	finBox := getCoords(fins[0]).box
+	for _, pos := range fins[1:] {
+		if getCoords(pos).box != finBox {
+			return nil
+		}
+	}
+
+	var coverLines []int
+	for line := 0; line < 9; line++ {
+		if cover&(1<<uint(line)) != 0 {
+			coverLines = append(coverLines, line)
+		}
+	}
+
+	var args []interface{}
+	for _, pos := range fins {
+		args = append(args, pos)
+	}
+	args = append(args, hint)
+	logFormat := fmt.Sprintf("base %s %s cover %s %s fins%s hint %%v",
+		dim.baseName, getFishLineNames(dim.baseName, baseLines),
+		dim.coverName, getFishLineNames(dim.coverName, coverLines),
+		strings.Repeat(" %v", len(fins)))
+
+	removeHint := func(target int, source int) error {
+		if getCoords(target).box != finBox || b.blits[target]&hint == 0 {
+			return nil
+		}
+		for _, line := range baseLines {
+			if dim.baseLine(target) == line {
+				return nil
+			}
+		}
+
+		logEntry, err := b.updateCandidates(target, ^hint)
+		if err != nil {
+			return err
+		}
+
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, logFormat, args...)
+		}
+		return nil
+	}
+
+	for _, line := range coverLines {
+		if err := dim.cover(dim.coverStart(line), removeHint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getFishLineNames returns the line names as shown on the board: row
+// letters or column numbers.
+func getFishLineNames(name string, lines []int) string {
+	var list []string
+	for _, line := range lines {
+		if name == "rows" {
+			list = append(list, string(rune(getTextRow(line))))
+		} else {
+			list = append(list, string(rune(getTextCol(line))))
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+// getBitIndexes returns the 0-based indexes of the set bits of val.
+func getBitIndexes(val uint) []int {
+	var list []int
+	for i := 0; val != 0; i++ {
+		if val&1 == 1 {
+			list = append(list, i)
+		}
+		val >>= 1
+	}
+	return list
+}
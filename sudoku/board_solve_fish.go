@@ -0,0 +1,163 @@
+package sudoku
+
+import "strings"
+
+// A basic fish of size n takes n base lines (rows or columns) in which a
+// hint is only possible in n cover lines of the other orientation. The n
+// base lines each hold the hint once, all within the cover lines, so the
+// hint can be removed from the rest of the cover lines.
+//
+// http://www.sudokuwiki.org/x_wing_strategy
+// http://www.sudokuwiki.org/Sword_Fish_Strategy
+// http://www.sudokuwiki.org/Jelly_Fish_Strategy
+
+// fishOrientation describes the base lines, the cover lines being the
+// other orientation. Lines are numbered 0-8.
+type fishOrientation struct {
+	baseName   string
+	coverName  string
+	base       containerOperator
+	cover      containerOperator
+	baseStart  func(line int) int
+	coverStart func(line int) int
+	baseLine   func(pos int) int
+	coverLine  func(pos int) int
+}
+
+func (b *Board) getFishOrientations() []fishOrientation {
+	row := func(pos int) int { return pos / 9 }
+	col := func(pos int) int { return pos % 9 }
+	rowStart := func(row int) int { return row * 9 }
+	colStart := func(col int) int { return col }
+
+	return []fishOrientation{
+		{
+			baseName:   "rows",
+			coverName:  "columns",
+			base:       b.operateOnRow,
+			cover:      b.operateOnColumn,
+			baseStart:  rowStart,
+			coverStart: colStart,
+			baseLine:   row,
+			coverLine:  col,
+		},
+		{
+			baseName:   "columns",
+			coverName:  "rows",
+			base:       b.operateOnColumn,
+			cover:      b.operateOnRow,
+			baseStart:  colStart,
+			coverStart: rowStart,
+			baseLine:   col,
+			coverLine:  row,
+		},
+	}
+}
+
+func (b *Board) SolveXWing() error {
+	return b.solveFish("X-WING", 2)
+}
+
+func (b *Board) SolveSwordFish() error {
+	return b.solveFish("SWORDFISH", 3)
+}
+
+func (b *Board) SolveJellyfish() error {
+	return b.solveFish("JELLYFISH", 4)
+}
+
+// solveFish looks for fish of the given size in rows then columns, hint by
+// hint so the solve path doesn't depend on map order.
+func (b *Board) solveFish(technique string, size int) error {
+	for _, dim := range b.getFishOrientations() {
+		for hint := uint(1); hint <= 0x100; hint <<= 1 {
+			cells, covers := b.getFishLines(dim, hint)
+
+			// a line with a single cell is a hidden single
+			var lines []int
+			for line := 0; line < 9; line++ {
+				if n := len(cells[line]); n >= 2 && n <= size {
+					lines = append(lines, line)
+				}
+			}
+
+			for _, baseLines := range getPermutations(size, lines, []int{}) {
+				var cover uint
+				for _, line := range baseLines {
+					cover |= covers[line]
+				}
+				if GetNumberOfSetBits(cover) != uint(size) {
+					continue
+				}
+
+				if err := b.fishApply(technique, dim, hint, baseLines, cells, cover); err != nil {
+					return err
+				}
+				if b.changed {
+					// let simpler techniques take over
+					return nil
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// getFishLines returns the unsolved cells with hint in each base line, and
+// the cover lines they're in as a bit mask.
+func (b *Board) getFishLines(dim fishOrientation, hint uint) ([9][]int, [9]uint) {
+	var cells [9][]int
+	var covers [9]uint
+	for line := 0; line < 9; line++ {
+		_ = dim.base(dim.baseStart(line), func(target int, source int) error {
+			if b.solved[target] == 0 && b.blits[target]&hint == hint {
+				cells[line] = append(cells[line], target)
+				covers[line] |= 1 << uint(dim.coverLine(target))
+			}
+			return nil
+		})
+	}
+	return cells, covers
+}
+
+// fishApply removes hint from the cover lines outside the base lines.
+func (b *Board) fishApply(technique string, dim fishOrientation, hint uint, baseLines []int, cells [9][]int, cover uint) error {
+	var args []interface{}
+	for _, line := range baseLines {
+		for _, pos := range cells[line] {
+			args = append(args, pos)
+		}
+	}
+	logFormat := strings.Repeat("%v ", len(args)) + "hint %v"
+	args = append(args, hint)
+
+	removeHint := func(target int, source int) error {
+		for _, line := range baseLines {
+			if dim.baseLine(target) == line {
+				return nil
+			}
+		}
+
+		logEntry, err := b.updateCandidates(target, ^hint)
+		if err != nil {
+			return err
+		}
+
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, logFormat, args...)
+		}
+		return nil
+	}
+
+	for line := 0; line < 9; line++ {
+		if cover&(1<<uint(line)) == 0 {
+			continue
+		}
+		if err := dim.cover(dim.coverStart(line), removeHint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
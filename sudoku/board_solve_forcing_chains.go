@@ -0,0 +1,335 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Forcing chains follow the implications of assuming candidates true or
+// false, placing naked and hidden singles and removing the candidates they
+// rule out. If every possibility of a cell (cell forcing), of a digit in a
+// unit (unit forcing), or a candidate both true and false (digit forcing)
+// leads to the same fact, the fact is true. A possibility leading to a
+// contradiction is false.
+//
+// http://www.sudokuwiki.org/Cell_Forcing_Chains
+// http://www.sudokuwiki.org/Unit_Forcing_Chains
+// http://www.sudokuwiki.org/Digit_Forcing_Chains
+
+// A forcing fact is a cell being a digit, pos*9+digit-1, or not being it,
+// offset by forcingOff.
+const (
+	forcingOff   = 81 * 9
+	forcingFacts = forcingOff * 2
+	forcingStart = -2
+)
+
+func forcingFact(pos int, hint uint, on bool) int {
+	fact := pos*9 + int(GetSingleBitValue(hint)) - 1
+	if !on {
+		fact += forcingOff
+	}
+	return fact
+}
+
+// forcingState holds the candidates following an assumption, and for every
+// fact implied the fact implying it.
+type forcingState struct {
+	blits         [81]uint
+	parent        [forcingFacts]int
+	queue         []int
+	contradiction int
+}
+
+func (b *Board) newForcingState() *forcingState {
+	s := &forcingState{blits: b.blits, contradiction: -1}
+	for i := range s.parent {
+		s.parent[i] = -1
+	}
+	return s
+}
+
+func (s *forcingState) has(fact int) bool {
+	return s.parent[fact] != -1
+}
+
+func (s *forcingState) setOn(pos int, hint uint, parent int) {
+	fact := forcingFact(pos, hint, true)
+	if s.has(fact) || s.contradiction != -1 {
+		return
+	}
+	s.parent[fact] = parent
+	if s.blits[pos]&hint == 0 {
+		s.contradiction = fact
+		return
+	}
+	s.queue = append(s.queue, fact)
+}
+
+func (s *forcingState) setOff(pos int, hint uint, parent int) {
+	fact := forcingFact(pos, hint, false)
+	if s.has(fact) || s.contradiction != -1 {
+		return
+	}
+	s.parent[fact] = parent
+	s.blits[pos] &^= hint
+	if s.blits[pos] == 0 {
+		s.contradiction = fact
+		return
+	}
+	s.queue = append(s.queue, fact)
+}
+
+// propagate follows the implications of the queued facts until there are
+// no more or a contradiction is found.
+func (s *forcingState) propagate() {
+	for len(s.queue) != 0 && s.contradiction == -1 {
+		fact := s.queue[0]
+		s.queue = s.queue[1:]
+
+		on := fact < forcingOff
+		pos := fact % forcingOff / 9
+		hint := uint(1) << uint(fact%9)
+
+		if on {
+			// the other candidates of the cell, and the hint in its peers
+			for _, other := range GetBitList(s.blits[pos] &^ hint) {
+				s.setOff(pos, other, fact)
+			}
+			for _, peer := range brutePeers[pos] {
+				if s.blits[peer]&hint != 0 {
+					s.setOff(peer, hint, fact)
+				}
+			}
+			continue
+		}
+
+		// naked single
+		if HasSingleBit(s.blits[pos]) {
+			s.setOn(pos, s.blits[pos], fact)
+		}
+
+		// hidden singles in the units of pos
+		c := getCoords(pos)
+		for _, unit := range []int{c.row, 9 + c.col, 18 + c.box} {
+			last := -1
+			count := 0
+			for _, target := range bruteUnits[unit] {
+				if s.blits[target]&hint != 0 {
+					last = target
+					count++
+				}
+			}
+			if count == 0 {
+				s.contradiction = fact
+				return
+			}
+			if count == 1 {
+				s.setOn(last, hint, fact)
+			}
+		}
+	}
+}
+
+// getForcingState returns the state following the facts assumed.
+func (b *Board) getForcingState(facts ...int) *forcingState {
+	s := b.newForcingState()
+	for _, fact := range facts {
+		pos := fact % forcingOff / 9
+		hint := uint(1) << uint(fact%9)
+		if fact < forcingOff {
+			s.setOn(pos, hint, forcingStart)
+		} else {
+			s.setOff(pos, hint, forcingStart)
+		}
+	}
+	s.propagate()
+	return s
+}
+
+// chain returns the implications from the assumption to fact,
+// e.g. "A1=3 => A2<>3 => A2=5".
+func (s *forcingState) chain(fact int) string {
+	var list []string
+	for ; fact >= 0; fact = s.parent[fact] {
+		list = append([]string{forcingFactString(fact)}, list...)
+	}
+	return strings.Join(list, " => ")
+}
+
+func forcingFactString(fact int) string {
+	pos := fact % forcingOff / 9
+	digit := fact%9 + 1
+	if fact < forcingOff {
+		return fmt.Sprintf("%v=%d", getCoords(pos), digit)
+	}
+	return fmt.Sprintf("%v<>%d", getCoords(pos), digit)
+}
+
+// forcingApply applies the first fact implied by all the states which isn't
+// known yet, or removes the assumptions leading to a contradiction.
+func (b *Board) forcingApply(technique string, description string, assumptions []int, states []*forcingState) error {
+	for i, s := range states {
+		if s.contradiction == -1 {
+			continue
+		}
+
+		// the assumption is false
+		fact := assumptions[i]
+		pos := fact % forcingOff / 9
+		hint := uint(1) << uint(fact%9)
+		mask := ^hint
+		if fact >= forcingOff {
+			mask = hint
+		}
+
+		logEntry, err := b.updateCandidates(pos, mask)
+		if err != nil {
+			return err
+		}
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, "%s: %s => contradiction, %s is false",
+				description, s.chain(s.contradiction), forcingFactString(fact))
+		}
+		return nil
+	}
+
+factLoop:
+	for fact := 0; fact < forcingFacts; fact++ {
+		for _, s := range states {
+			if !s.has(fact) {
+				continue factLoop
+			}
+		}
+
+		pos := fact % forcingOff / 9
+		hint := uint(1) << uint(fact%9)
+		if b.solved[pos] != 0 {
+			continue
+		}
+
+		var chains []string
+		for _, s := range states {
+			chains = append(chains, s.chain(fact))
+		}
+
+		var logEntry *updateLog
+		var err error
+		if fact < forcingOff {
+			logEntry, err = b.updateCandidates(pos, hint)
+		} else {
+			logEntry, err = b.updateCandidates(pos, ^hint)
+		}
+		if err != nil {
+			return err
+		}
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, "%s: %s, so %s", description, strings.Join(chains, " | "), forcingFactString(fact))
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// SolveCellForcingChains tries every candidate of a cell.
+func (b *Board) SolveCellForcingChains() error {
+	const technique = "CELL FORCING CHAINS"
+
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] != 0 || HasSingleBit(b.blits[pos]) {
+			continue
+		}
+
+		var assumptions []int
+		var states []*forcingState
+		for _, hint := range GetBitList(b.blits[pos]) {
+			fact := forcingFact(pos, hint, true)
+			assumptions = append(assumptions, fact)
+			states = append(states, b.getForcingState(fact))
+		}
+
+		description := fmt.Sprintf("cell %v", getCoords(pos))
+		if err := b.forcingApply(technique, description, assumptions, states); err != nil {
+			return err
+		}
+		if b.changed {
+			// let simpler techniques take over
+			return nil
+		}
+	}
+	return nil
+}
+
+// SolveUnitForcingChains tries every cell of a unit where a digit can go.
+func (b *Board) SolveUnitForcingChains() error {
+	const technique = "UNIT FORCING CHAINS"
+
+	for u, unit := range bruteUnits {
+		for hint := uint(1); hint <= 0x100; hint <<= 1 {
+			var cells []int
+			for _, pos := range unit {
+				if b.blits[pos]&hint != 0 {
+					cells = append(cells, pos)
+				}
+			}
+			if len(cells) < 2 {
+				continue
+			}
+
+			var assumptions []int
+			var states []*forcingState
+			for _, pos := range cells {
+				fact := forcingFact(pos, hint, true)
+				assumptions = append(assumptions, fact)
+				states = append(states, b.getForcingState(fact))
+			}
+
+			var description string
+			switch digit := GetSingleBitValue(hint); u / 9 {
+			case 0:
+				description = fmt.Sprintf("%d in row %c", digit, getTextRow(u))
+			case 1:
+				description = fmt.Sprintf("%d in column %c", digit, getTextCol(u-9))
+			default:
+				description = fmt.Sprintf("%d in box %d", digit, u-17)
+			}
+			if err := b.forcingApply(technique, description, assumptions, states); err != nil {
+				return err
+			}
+			if b.changed {
+				// let simpler techniques take over
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// SolveDigitForcingChains tries every candidate true and false.
+func (b *Board) SolveDigitForcingChains() error {
+	const technique = "DIGIT FORCING CHAINS"
+
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] != 0 || HasSingleBit(b.blits[pos]) {
+			continue
+		}
+
+		for _, hint := range GetBitList(b.blits[pos]) {
+			on := forcingFact(pos, hint, true)
+			off := forcingFact(pos, hint, false)
+			assumptions := []int{on, off}
+			states := []*forcingState{b.getForcingState(on), b.getForcingState(off)}
+
+			description := fmt.Sprintf("digit %d in %v", GetSingleBitValue(hint), getCoords(pos))
+			if err := b.forcingApply(technique, description, assumptions, states); err != nil {
+				return err
+			}
+			if b.changed {
+				// let simpler techniques take over
+				return nil
+			}
+		}
+	}
+	return nil
+}
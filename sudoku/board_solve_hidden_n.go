@@ -1,11 +1,11 @@
-package main
+package sudoku
 
 import (
 	"fmt"
 	"strings"
 )
 
-func (b *board) SolveHiddenN(n int) error {
+func (b *Board) SolveHiddenN(n int) error {
 	if n < 2 || n > 5 {
 		return fmt.Errorf("n must be between [2,5], actual=%d", n)
 	}
@@ -60,7 +60,7 @@ func (b *board) SolveHiddenN(n int) error {
 	return nil
 }
 
-func (b *board) checkHiddenPermutations(n int, source int, op containerOperator, lists [][]int) error {
+func (b *Board) checkHiddenPermutations(n int, source int, op containerOperator, lists [][]int) error {
 	const techniqueFormat = "HIDDEN-%s"
 
 	var err error
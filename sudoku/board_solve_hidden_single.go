@@ -1,8 +1,8 @@
-package main
+package sudoku
 
 import "fmt"
 
-func (b *board) SolveHiddenSingle() error {
+func (b *Board) SolveHiddenSingle() error {
 	// Hidden Single - a given cell contains a candidate which is only
 	// present in this cell and not in the rest of the row/column/box
 	const technique = "HIDDEN-SINGLE"
@@ -0,0 +1,277 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Coloring follows strong links, giving their ends opposite colors: one
+// color is true and the other false. 3D Medusa colors the candidates of all
+// digits, linking the two candidates of bivalue cells as well as conjugate
+// pairs, and Multi-Coloring compares the clusters of a single digit.
+//
+// http://www.sudokuwiki.org/3D_Medusa
+// http://www.sudokuwiki.org/Multi_Colouring
+
+// colorCluster holds the candidates of each color of a cluster as hint
+// masks per cell.
+type colorCluster [2][81]uint
+
+// getColorClusters returns the clusters linked by conjugate pairs of hints,
+// and bivalue cells with medusa, in cell then hint order.
+func (b *Board) getColorClusters(hints uint, medusa bool) []*colorCluster {
+	var list []*colorCluster
+	var colored [81]uint
+
+	type colorNode struct {
+		pos   int
+		hint  uint
+		color int
+	}
+
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] != 0 {
+			continue
+		}
+		for _, hint := range GetBitList(b.blits[pos] & hints &^ colored[pos]) {
+			cl := &colorCluster{}
+			size := 0
+			queue := []colorNode{{pos: pos, hint: hint}}
+			cl[0][pos] |= hint
+			colored[pos] |= hint
+
+			for len(queue) != 0 {
+				node := queue[0]
+				queue = queue[1:]
+				size++
+
+				var links []colorNode
+				for _, target := range b.getStrongLinks(node.pos, node.hint) {
+					links = append(links, colorNode{pos: target, hint: node.hint, color: 1 - node.color})
+				}
+				if medusa && GetNumberOfSetBits(b.blits[node.pos]) == 2 {
+					links = append(links, colorNode{pos: node.pos, hint: b.blits[node.pos] &^ node.hint, color: 1 - node.color})
+				}
+
+				for _, link := range links {
+					if colored[link.pos]&link.hint != 0 {
+						continue
+					}
+					cl[link.color][link.pos] |= link.hint
+					colored[link.pos] |= link.hint
+					queue = append(queue, link)
+				}
+			}
+
+			if size > 1 {
+				list = append(list, cl)
+			}
+		}
+	}
+	return list
+}
+
+// sees returns the candidates of color seeing hint in pos.
+func (cl *colorCluster) sees(color int, pos int, hint uint) []int {
+	var list []int
+	for _, peer := range brutePeers[pos] {
+		if cl[color][peer]&hint != 0 {
+			list = append(list, peer)
+		}
+	}
+	return list
+}
+
+// String returns the candidates of each color, e.g. "A1=3 B2=5 / A2=3".
+func (cl *colorCluster) String() string {
+	var colors []string
+	for color := 0; color < 2; color++ {
+		var list []string
+		for pos := 0; pos < 81; pos++ {
+			for _, hint := range GetBitList(cl[color][pos]) {
+				list = append(list, fmt.Sprintf("%v=%d", getCoords(pos), GetSingleBitValue(hint)))
+			}
+		}
+		colors = append(colors, strings.Join(list, " "))
+	}
+	return strings.Join(colors, " / ")
+}
+
+// removeColor removes the candidates of a color found false.
+func (b *Board) removeColor(technique string, cl *colorCluster, color int, reason string) error {
+	for pos := 0; pos < 81; pos++ {
+		if cl[color][pos] == 0 {
+			continue
+		}
+		logEntry, err := b.updateCandidates(pos, ^cl[color][pos])
+		if err != nil {
+			return err
+		}
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, "%s", fmt.Sprintf("colors %s, color %d is false: %s", cl, color+1, reason))
+		}
+	}
+	return nil
+}
+
+// removeUncolored removes a candidate which isn't colored.
+func (b *Board) removeUncolored(technique string, cl *colorCluster, pos int, hint uint, reason string) error {
+	logEntry, err := b.updateCandidates(pos, ^hint)
+	if err != nil {
+		return err
+	}
+	if logEntry != nil {
+		b.AddLog(technique, logEntry, "colors %s, %s", cl, reason)
+	}
+	return nil
+}
+
+// SolveMedusa applies the six rules of 3D Medusa to each cluster. A color
+// is false when it's twice in a cell (1), twice in a house for a hint (2),
+// or when it sees all the candidates of an uncolored cell (6). An uncolored
+// candidate is removed when its cell has both colors (3), when it sees both
+// colors (4), or when it sees one color and its cell has the other (5).
+func (b *Board) SolveMedusa() error {
+	const technique = "3D MEDUSA"
+
+	for _, cl := range b.getColorClusters(0x1FF, true) {
+		for color := 0; color < 2; color++ {
+			var reason string
+			for pos := 0; pos < 81; pos++ {
+				hints := cl[color][pos]
+				if GetNumberOfSetBits(hints) > 1 {
+					reason = fmt.Sprintf("twice in %v", getCoords(pos))
+					break
+				}
+				if hints != 0 && len(cl.sees(color, pos, hints)) != 0 {
+					reason = fmt.Sprintf("%v twice in a house", GetSingleBitValue(hints))
+					break
+				}
+
+				// an uncolored cell all of whose candidates see the color
+				if b.solved[pos] != 0 || cl[0][pos]|cl[1][pos] != 0 {
+					continue
+				}
+				empty := true
+				for _, hint := range GetBitList(b.blits[pos]) {
+					if len(cl.sees(color, pos, hint)) == 0 {
+						empty = false
+						break
+					}
+				}
+				if empty {
+					reason = fmt.Sprintf("%v emptied", getCoords(pos))
+					break
+				}
+			}
+
+			if reason != "" {
+				if err := b.removeColor(technique, cl, color, reason); err != nil {
+					return err
+				}
+				// let simpler techniques take over
+				return nil
+			}
+		}
+
+		for pos := 0; pos < 81; pos++ {
+			if b.solved[pos] != 0 {
+				continue
+			}
+			colored := cl[0][pos] | cl[1][pos]
+			for _, hint := range GetBitList(b.blits[pos] &^ colored) {
+				var reason string
+				switch {
+				case cl[0][pos] != 0 && cl[1][pos] != 0:
+					reason = fmt.Sprintf("%v has both colors", getCoords(pos))
+				case len(cl.sees(0, pos, hint)) != 0 && len(cl.sees(1, pos, hint)) != 0:
+					reason = fmt.Sprintf("%v=%d sees both colors", getCoords(pos), GetSingleBitValue(hint))
+				case cl[0][pos] != 0 && len(cl.sees(1, pos, hint)) != 0,
+					cl[1][pos] != 0 && len(cl.sees(0, pos, hint)) != 0:
+					reason = fmt.Sprintf("%v=%d sees the other color of its cell", getCoords(pos), GetSingleBitValue(hint))
+				default:
+					continue
+				}
+				if err := b.removeUncolored(technique, cl, pos, hint, reason); err != nil {
+					return err
+				}
+			}
+		}
+		if b.changed {
+			// let simpler techniques take over
+			return nil
+		}
+	}
+	return nil
+}
+
+// SolveMultiColoring compares the single hint clusters two by two. When a
+// color of one cluster sees both colors of the other it's false. When a
+// color of each cluster see each other one of their other colors is true,
+// and the hint can be removed from the cells seeing both.
+func (b *Board) SolveMultiColoring() error {
+	const technique = "MULTI-COLORING"
+
+	for hint := uint(1); hint <= 0x100; hint <<= 1 {
+		clusters := b.getColorClusters(hint, false)
+		for i, cl1 := range clusters {
+			for _, cl2 := range clusters {
+				if cl1 == cl2 {
+					continue
+				}
+
+				for c1 := 0; c1 < 2; c1++ {
+					for pos := 0; pos < 81; pos++ {
+						if cl1[c1][pos] == 0 || len(cl2.sees(0, pos, hint)) == 0 || len(cl2.sees(1, pos, hint)) == 0 {
+							continue
+						}
+						reason := fmt.Sprintf("%v sees both colors of %s", getCoords(pos), cl2)
+						if err := b.removeColor(technique, cl1, c1, reason); err != nil {
+							return err
+						}
+						// let simpler techniques take over
+						return nil
+					}
+				}
+			}
+
+			for _, cl2 := range clusters[i+1:] {
+				for c1 := 0; c1 < 2; c1++ {
+					for c2 := 0; c2 < 2; c2++ {
+						if !cl1.seesColor(c1, cl2, c2) {
+							continue
+						}
+
+						// one of the other colors is true
+						for pos := 0; pos < 81; pos++ {
+							if b.solved[pos] != 0 || b.blits[pos]&hint == 0 ||
+								cl1[0][pos]|cl1[1][pos]|cl2[0][pos]|cl2[1][pos] != 0 ||
+								len(cl1.sees(1-c1, pos, hint)) == 0 || len(cl2.sees(1-c2, pos, hint)) == 0 {
+								continue
+							}
+							reason := fmt.Sprintf("and %s, colors %d and %d see each other", cl2, c1+1, c2+1)
+							if err := b.removeUncolored(technique, cl1, pos, hint, reason); err != nil {
+								return err
+							}
+						}
+						if b.changed {
+							// let simpler techniques take over
+							return nil
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// seesColor returns true if a candidate of color sees one of color2 in cl2.
+func (cl *colorCluster) seesColor(color int, cl2 *colorCluster, color2 int) bool {
+	for pos := 0; pos < 81; pos++ {
+		if cl[color][pos] != 0 && len(cl2.sees(color2, pos, cl[color][pos])) != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,11 @@
-package main
+package sudoku
 
 import (
 	"fmt"
 	"strings"
 )
 
-func (b *board) SolveNakedN(n int) error {
+func (b *Board) SolveNakedN(n int) error {
 	// When a cell has N candidates and (N-1) other cells have combined
 	// hints equal to the N candidates, then all N candidates can be removed
 	// from the rest of the cells in common.
@@ -1,10 +1,10 @@
-package main
+package sudoku
 
 import (
 	"fmt"
 )
 
-func (b *board) SolveNakedSingle() error {
+func (b *Board) SolveNakedSingle() error {
 	// Naked Single - only hint left
 	const technique = "NAKED-SINGLE"
 
@@ -1,10 +1,10 @@
-package main
+package sudoku
 
 import (
 	"strings"
 )
 
-func (b *board) SolvePointingPairAndTripleReduction() error {
+func (b *Board) SolvePointingPairAndTripleReduction() error {
 	// http://planetsudoku.com/how-to/sudoku-pointing-pair-and-triple.html
 	// "I have two or three unique HINTS within a shared box, sharing the same
 	// ROW or COLUMN. Therefore that hint cannot belong anywhere else on that
@@ -0,0 +1,78 @@
+package sudoku
+
+import "strings"
+
+// SolveRemotePairs looks for chains of bivalue cells with the same two
+// hints, each seeing the next. The cells alternate between the two hints,
+// so cells an odd number of links apart hold both of them between them,
+// and both hints can be removed from the cells seeing the two. Chains of
+// less than four cells are left to naked pairs.
+//
+// http://www.sudokuwiki.org/Remote_Pairs
+func (b *Board) SolveRemotePairs() error {
+	const technique = "REMOTE PAIRS"
+
+	var done [81]bool
+	for start := 0; start < 81; start++ {
+		blit := b.blits[start]
+		if done[start] || b.solved[start] != 0 || GetNumberOfSetBits(blit) != 2 {
+			continue
+		}
+
+		// color the chain the cell is in, breadth first
+		color := map[int]int{start: 0}
+		chain := []int{start}
+		for i := 0; i < len(chain); i++ {
+			for _, pos := range b.getVisibleCells(chain[i]) {
+				if _, ok := color[pos]; ok || b.blits[pos] != blit {
+					continue
+				}
+				color[pos] = 1 - color[chain[i]]
+				chain = append(chain, pos)
+			}
+		}
+		for _, pos := range chain {
+			done[pos] = true
+		}
+		if len(chain) < 4 {
+			continue
+		}
+
+		var args []interface{}
+		for _, pos := range chain {
+			args = append(args, pos)
+		}
+		args = append(args, blit)
+		logFormat := "chain=" + strings.Repeat("%v ", len(chain)) + "hint %v"
+
+		for target := 0; target < 81; target++ {
+			if _, ok := color[target]; ok || b.solved[target] != 0 || b.blits[target]&blit == 0 {
+				continue
+			}
+
+			var sees [2]bool
+			for _, pos := range chain {
+				if canSee(target, pos) {
+					sees[color[pos]] = true
+				}
+			}
+			if !sees[0] || !sees[1] {
+				continue
+			}
+
+			logEntry, err := b.updateCandidates(target, ^blit)
+			if err != nil {
+				return err
+			}
+			if logEntry != nil {
+				b.AddLog(technique, logEntry, logFormat, args...)
+			}
+		}
+		if b.changed {
+			// let simpler techniques take over
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package sudoku
+
+import "sort"
+
+func (b *Board) SolveSAT() error {
+	satInput := b.getSAT()
+	satSolver, err := NewSAT(satInput, false, 0)
+	if err != nil {
+		return err
+	}
+
+	slns := satSolver.Solve()
+	if slns == nil || len(slns) == 0 {
+		return NewErrUnsolvable("could not solve with SAT")
+	}
+
+	placements := b.applySAT(slns[0])
+	if err = b.Validate(); err != nil {
+		return err
+	}
+
+	b.AddLog("SAT", nil, "Solved with SAT")
+	sort.Ints(placements)
+	for _, pos := range placements {
+		b.addLogPlacement(pos, b.solved[pos])
+	}
+
+	return nil
+}
+
+// applySAT places the values set in a SAT solution and returns the
+// positions which were unsolved.
+func (b *Board) applySAT(sln *SAT) []int {
+	var placements []int
+	for _, setvar := range sln.SetVars {
+		k := int(setvar.VarNum)
+		v := setvar.Value
+		if v {
+			r := k/100 - 1
+			c := (k%100)/10 - 1
+			pos := r*9 + c
+			if b.solved[pos] == 0 {
+				val := k % 10
+				b.SolvePositionNoValidate(pos, uint(val))
+				placements = append(placements, pos)
+			}
+		}
+	}
+	return placements
+}
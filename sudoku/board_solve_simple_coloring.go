@@ -1,11 +1,11 @@
-package main
+package sudoku
 
 import (
 	"sort"
 	"strings"
 )
 
-func (b *board) SolveSimpleColoring() error {
+func (b *Board) SolveSimpleColoring() error {
 	const technique = "SIMPLE-COLORING"
 
 	var err error
@@ -98,9 +98,17 @@ valueLoop:
 		// we need to consider only contiguous chains
 		// it's possible to have two distinct chains with the same hint
 		for len(cellPeers) != 0 {
+			// visit cells in order so the chain found doesn't depend on map order
+			var keys []int
+			for k := range cellPeers {
+				keys = append(keys, k)
+			}
+			sort.Ints(keys)
+
 			posColor := make(map[int]int)
 			i := 0
-			for k, v := range cellPeers {
+			for _, k := range keys {
+				v := cellPeers[k]
 				color, ok := posColor[k]
 				if !ok {
 					if i != 0 {
@@ -136,6 +144,8 @@ valueLoop:
 					color1 = append(color1, k)
 				}
 			}
+			sort.Ints(color0)
+			sort.Ints(color1)
 
 			if len(color0) != len(color1) {
 				continue
@@ -159,7 +169,7 @@ valueLoop:
 
 							if logEntry != nil {
 								var args []interface{}
-								for k := range posColor {
+								for _, k := range union(color0, color1) {
 									args = append(args, k)
 								}
 								args = append(args, pos0)
@@ -0,0 +1,121 @@
+package sudoku
+
+// A Turbot Fish is a chain of two strong links for a hint, conjugate pairs
+// in houses, joined by a weak link: one end of the first sees one end of
+// the second. One of the other two ends has the hint, so it can be removed
+// from the cells seeing both. A Skyscraper has both strong links in rows,
+// or in columns, joined in a column, or a row. A 2-String Kite has a row
+// and a column joined in a box.
+//
+// http://hodoku.sourceforge.net/en/tech_sdp.php
+
+const (
+	turbotSkyscraper = iota
+	turbotKite
+	turbotFish
+)
+
+// conjugatePair is the two cells with a hint in a unit of bruteUnits.
+type conjugatePair struct {
+	cells [2]int
+	unit  int
+}
+
+// getConjugatePairs returns the conjugate pairs of hint in rows, columns
+// then boxes, leaving out box pairs already found in a row or column.
+func (b *Board) getConjugatePairs(hint uint) []conjugatePair {
+	var list []conjugatePair
+	for u, unit := range bruteUnits {
+		var cells []int
+		for _, pos := range unit {
+			if b.solved[pos] == 0 && b.blits[pos]&hint != 0 {
+				cells = append(cells, pos)
+			}
+		}
+		if len(cells) != 2 {
+			continue
+		}
+		if u >= 18 && hasConjugatePair(list, cells[0], cells[1]) {
+			continue
+		}
+		list = append(list, conjugatePair{cells: [2]int{cells[0], cells[1]}, unit: u})
+	}
+	return list
+}
+
+func hasConjugatePair(list []conjugatePair, pos1 int, pos2 int) bool {
+	for _, pair := range list {
+		if pair.cells[0] == pos1 && pair.cells[1] == pos2 {
+			return true
+		}
+	}
+	return false
+}
+
+// getTurbotKind returns the kind of chain made by the strong links l1 and
+// l2 joined by a weak link from the cell q of l1 to the cell r of l2.
+func getTurbotKind(l1 conjugatePair, q int, l2 conjugatePair, r int) int {
+	c1, c2 := getCoords(q), getCoords(r)
+	switch {
+	case l1.unit/9 == 0 && l2.unit/9 == 0 && c1.col == c2.col,
+		l1.unit/9 == 1 && l2.unit/9 == 1 && c1.row == c2.row:
+		return turbotSkyscraper
+	case l1.unit/9 != 2 && l2.unit/9 != 2 && l1.unit/9 != l2.unit/9 && c1.box == c2.box:
+		return turbotKite
+	default:
+		return turbotFish
+	}
+}
+
+func (b *Board) SolveSkyscraper() error {
+	return b.solveTurbot("SKYSCRAPER", turbotSkyscraper)
+}
+
+func (b *Board) SolveTwoStringKite() error {
+	return b.solveTurbot("2-STRING KITE", turbotKite)
+}
+
+func (b *Board) SolveTurbotFish() error {
+	return b.solveTurbot("TURBOT FISH", turbotFish)
+}
+
+// solveTurbot looks for chains of two strong links of the given kind.
+func (b *Board) solveTurbot(technique string, kind int) error {
+	for hint := uint(1); hint <= 0x100; hint <<= 1 {
+		links := b.getConjugatePairs(hint)
+		for i, l1 := range links {
+			for _, l2 := range links[i+1:] {
+				for _, ends1 := range [][2]int{{0, 1}, {1, 0}} {
+					for _, ends2 := range [][2]int{{0, 1}, {1, 0}} {
+						p, q := l1.cells[ends1[0]], l1.cells[ends1[1]]
+						r, s := l2.cells[ends2[0]], l2.cells[ends2[1]]
+						if p == r || p == s || q == r || q == s || !canSee(q, r) {
+							continue
+						}
+						if getTurbotKind(l1, q, l2, r) != kind {
+							continue
+						}
+
+						for _, target := range b.getVisibleCellsWithHint(p, hint) {
+							if target == q || target == r || target == s || !canSee(target, s) {
+								continue
+							}
+							logEntry, err := b.updateCandidates(target, ^hint)
+							if err != nil {
+								return err
+							}
+							if logEntry != nil {
+								b.AddLog(technique, logEntry, "%v=%v-%v=%v hint %v", p, q, r, s, hint)
+							}
+						}
+						if b.changed {
+							// let simpler techniques take over
+							return nil
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,372 @@
+package sudoku
+
+import "strings"
+
+// Unique rectangles rely on the puzzle having a single solution: four cells
+// in two rows, two columns and two boxes can't be left with only the same
+// two candidates (the deadly pattern), otherwise the two digits could be
+// swapped to give a second solution. They are only used once SetAssumeUnique
+// is set.
+//
+// http://www.sudokuwiki.org/Unique_Rectangles
+// http://www.sudokuwiki.org/Hidden_Unique_Rectangles
+
+// uniqueRect holds the corners of a rectangle: the top left, top right,
+// bottom left and bottom right cells. Corner i and i^1 share a row, corner
+// i and i^2 share a column and corner i and i^3 are diagonal.
+type uniqueRect [4]int
+
+var uniqueRects []uniqueRect
+
+func init() {
+	for r1 := 0; r1 < 9; r1++ {
+		for r2 := r1 + 1; r2 < 9; r2++ {
+			for c1 := 0; c1 < 9; c1++ {
+				for c2 := c1 + 1; c2 < 9; c2++ {
+					// the rectangle must span exactly two boxes
+					if (r1/3 == r2/3) == (c1/3 == c2/3) {
+						continue
+					}
+					uniqueRects = append(uniqueRects, uniqueRect{r1*9 + c1, r1*9 + c2, r2*9 + c1, r2*9 + c2})
+				}
+			}
+		}
+	}
+}
+
+// SetAssumeUnique enables the techniques which rely on the puzzle having a
+// single solution, such as unique rectangles. Only set it when the solution
+// is known or assumed to be unique; on a puzzle with several solutions these
+// techniques can remove candidates of valid solutions.
+func (b *Board) SetAssumeUnique(assumeUnique bool) {
+	b.assumeUnique = assumeUnique
+}
+
+// getUniqueRectPairs calls check for every rectangle of unsolved cells and
+// pair of digits x and y which are candidates in all four corners, until
+// the board is changed.
+func (b *Board) getUniqueRectPairs(check func(rect uniqueRect, x uint, y uint) error) error {
+	for _, rect := range uniqueRects {
+		common := uint(0x1FF)
+		for _, pos := range rect {
+			if b.solved[pos] != 0 {
+				common = 0
+				break
+			}
+			common &= b.blits[pos]
+		}
+		if GetNumberOfSetBits(common) < 2 {
+			continue
+		}
+
+		hints := GetBitList(common)
+		for i := 0; i < len(hints); i++ {
+			for j := i + 1; j < len(hints); j++ {
+				if err := check(rect, hints[i], hints[j]); err != nil {
+					return err
+				}
+				if b.changed {
+					// let simpler techniques take over
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SolveUniqueRectangles applies unique rectangle types 1 to 6.
+func (b *Board) SolveUniqueRectangles() error {
+	if !b.assumeUnique {
+		return nil
+	}
+
+	return b.getUniqueRectPairs(func(rect uniqueRect, x uint, y uint) error {
+		pair := x | y
+
+		// floor cells only have the pair, the roof cells have extra candidates
+		var floor, roof []int
+		for i, pos := range rect {
+			if b.blits[pos] == pair {
+				floor = append(floor, i)
+			} else {
+				roof = append(roof, i)
+			}
+		}
+
+		switch len(floor) {
+		case 3:
+			return b.uniqueRectType1(rect, pair, roof[0])
+		case 2:
+			if err := b.uniqueRectType2And5(rect, pair, roof); err != nil || b.changed {
+				return err
+			}
+			if floor[0]^floor[1] == 3 {
+				return b.uniqueRectType6(rect, x, y, roof)
+			}
+			if err := b.uniqueRectType3(rect, pair, roof); err != nil || b.changed {
+				return err
+			}
+			return b.uniqueRectType4(rect, x, y, roof)
+		case 1:
+			return b.uniqueRectType2And5(rect, pair, roof)
+		}
+		return nil
+	})
+}
+
+// uniqueRectType1: three corners only have the pair, so the pair can be
+// removed from the fourth.
+func (b *Board) uniqueRectType1(rect uniqueRect, pair uint, corner int) error {
+	const technique = "UNIQUE RECTANGLE"
+	const logFormat = "type 1: deadly pattern %v %v %v %v on %v, %v can't be %v"
+
+	target := rect[corner]
+	logEntry, err := b.updateCandidates(target, ^pair)
+	if err != nil {
+		return err
+	}
+	if logEntry != nil {
+		b.AddLog(technique, logEntry, logFormat, rect[0], rect[1], rect[2], rect[3], pair, target, pair)
+	}
+	return nil
+}
+
+// uniqueRectType2And5: the roof corners have the same single extra
+// candidate z, one of them must be z so z can be removed from the cells
+// seeing them all. The roof is a side of the rectangle in type 2, and the
+// diagonal or three corners in type 5.
+func (b *Board) uniqueRectType2And5(rect uniqueRect, pair uint, roof []int) error {
+	const technique = "UNIQUE RECTANGLE"
+
+	extra := b.blits[rect[roof[0]]] &^ pair
+	if !HasSingleBit(extra) {
+		return nil
+	}
+	for _, corner := range roof[1:] {
+		if b.blits[rect[corner]] != pair|extra {
+			return nil
+		}
+	}
+
+	logFormat := "type 5: deadly pattern %v %v %v %v on %v, one of the roof cells must be %v"
+	if len(roof) == 2 && roof[0]^roof[1] != 3 {
+		logFormat = "type 2: deadly pattern %v %v %v %v on %v, one of the roof cells must be %v"
+	}
+
+targetLoop:
+	for _, target := range b.getVisibleCellsWithHint(rect[roof[0]], extra) {
+		for _, corner := range roof[1:] {
+			if !canSee(target, rect[corner]) {
+				continue targetLoop
+			}
+		}
+
+		logEntry, err := b.updateCandidates(target, ^extra)
+		if err != nil {
+			return err
+		}
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, logFormat, rect[0], rect[1], rect[2], rect[3], pair, extra)
+		}
+	}
+	return nil
+}
+
+// uniqueRectType3: the roof corners share a unit and have extra candidates.
+// One of the extras must be in the roof, so the roof acts as one cell with
+// the extras as candidates which can form a naked subset with other cells
+// of the unit.
+func (b *Board) uniqueRectType3(rect uniqueRect, pair uint, roof []int) error {
+	const technique = "UNIQUE RECTANGLE"
+	const logFormat = "type 3: deadly pattern %v %v %v %v on %v, roof extras %v form a naked subset with %v"
+
+	roof1, roof2 := rect[roof[0]], rect[roof[1]]
+	extras := (b.blits[roof1] | b.blits[roof2]) &^ pair
+
+	for _, op := range b.getCommonOperators(roof1, roof2) {
+		var cells []int
+		if err := op(roof1, func(target int, source int) error {
+			if target != roof1 && target != roof2 && b.solved[target] == 0 {
+				cells = append(cells, target)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for n := 1; n <= 3 && n < len(cells); n++ {
+			for _, subset := range getPermutations(n, cells, nil) {
+				hints := extras
+				for _, pos := range subset {
+					hints |= b.blits[pos]
+				}
+				if GetNumberOfSetBits(hints) != uint(n+1) {
+					continue
+				}
+
+				var args []interface{}
+				args = append(args, rect[0], rect[1], rect[2], rect[3], pair, extras)
+				for _, pos := range subset {
+					args = append(args, pos)
+				}
+				format := logFormat + strings.Repeat(" %v", n-1)
+
+				for _, target := range subtract(cells, subset) {
+					logEntry, err := b.updateCandidates(target, ^hints)
+					if err != nil {
+						return err
+					}
+					if logEntry != nil {
+						b.AddLog(technique, logEntry, format, args...)
+					}
+				}
+				if b.changed {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueRectType4: the roof corners share a unit where one digit of the
+// pair is confined to the roof, so the roof can't have the other digit.
+func (b *Board) uniqueRectType4(rect uniqueRect, x uint, y uint, roof []int) error {
+	const technique = "UNIQUE RECTANGLE"
+	const logFormat = "type 4: deadly pattern %v %v %v %v on %v, %v is confined to %v %v so they can't be %v"
+
+	roof1, roof2 := rect[roof[0]], rect[roof[1]]
+	for _, op := range b.getCommonOperators(roof1, roof2) {
+		for _, hints := range [][2]uint{{x, y}, {y, x}} {
+			confined, other := hints[0], hints[1]
+			if len(b.getCellsWithHint(op, roof1, confined)) != 2 {
+				continue
+			}
+
+			for _, target := range []int{roof1, roof2} {
+				logEntry, err := b.updateCandidates(target, ^other)
+				if err != nil {
+					return err
+				}
+				if logEntry != nil {
+					b.AddLog(technique, logEntry, logFormat, rect[0], rect[1], rect[2], rect[3], x|y, confined, roof1, roof2, other)
+				}
+			}
+			if b.changed {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueRectType6: the floor corners are diagonal and one digit of the pair
+// is confined to the rectangle in both its rows or both its columns, making
+// an X-Wing. The digit is either on the floor or the roof diagonal, and the
+// roof would leave the deadly pattern, so it can be removed from the roof.
+func (b *Board) uniqueRectType6(rect uniqueRect, x uint, y uint, roof []int) error {
+	const technique = "UNIQUE RECTANGLE"
+	const logFormat = "type 6: deadly pattern %v %v %v %v on %v, %v is confined to the rectangle in two units so %v %v can't be %v"
+
+	for _, hint := range []uint{x, y} {
+		rows := len(b.getCellsWithHint(b.operateOnRow, rect[0], hint)) == 2 &&
+			len(b.getCellsWithHint(b.operateOnRow, rect[3], hint)) == 2
+		cols := len(b.getCellsWithHint(b.operateOnColumn, rect[0], hint)) == 2 &&
+			len(b.getCellsWithHint(b.operateOnColumn, rect[3], hint)) == 2
+		if !rows && !cols {
+			continue
+		}
+
+		for _, corner := range roof {
+			logEntry, err := b.updateCandidates(rect[corner], ^hint)
+			if err != nil {
+				return err
+			}
+			if logEntry != nil {
+				b.AddLog(technique, logEntry, logFormat, rect[0], rect[1], rect[2], rect[3], x|y, hint, rect[roof[0]], rect[roof[1]], hint)
+			}
+		}
+		if b.changed {
+			return nil
+		}
+	}
+	return nil
+}
+
+// SolveHiddenUniqueRectangles: a corner only has the pair, and one digit of
+// the pair is confined to the rectangle in the row and the column of the
+// opposite corner. If the opposite corner had the other digit the deadly
+// pattern would follow, so it can be removed.
+func (b *Board) SolveHiddenUniqueRectangles() error {
+	const technique = "HIDDEN UNIQUE RECTANGLE"
+	const logFormat = "deadly pattern %v %v %v %v on %v, %v is confined to the rectangle in the row and column of %v so it can't be %v"
+
+	if !b.assumeUnique {
+		return nil
+	}
+
+	return b.getUniqueRectPairs(func(rect uniqueRect, x uint, y uint) error {
+		pair := x | y
+		for corner, pos := range rect {
+			if b.blits[pos] != pair {
+				continue
+			}
+
+			opposite := rect[corner^3]
+			if b.blits[opposite] == pair {
+				continue
+			}
+
+			for _, hints := range [][2]uint{{x, y}, {y, x}} {
+				confined, other := hints[0], hints[1]
+				if len(b.getCellsWithHint(b.operateOnRow, opposite, confined)) != 2 ||
+					len(b.getCellsWithHint(b.operateOnColumn, opposite, confined)) != 2 {
+					continue
+				}
+
+				logEntry, err := b.updateCandidates(opposite, ^other)
+				if err != nil {
+					return err
+				}
+				if logEntry != nil {
+					b.AddLog(technique, logEntry, logFormat, rect[0], rect[1], rect[2], rect[3], pair, confined, opposite, other)
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// getCellsWithHint returns the unsolved cells with hint in the container of
+// pos visited by op, pos included.
+func (b *Board) getCellsWithHint(op containerOperator, pos int, hint uint) []int {
+	var list []int
+	_ = op(pos, func(target int, source int) error {
+		if b.solved[target] == 0 && b.blits[target]&hint != 0 {
+			list = append(list, target)
+		}
+		return nil
+	})
+	return list
+}
+
+// getCommonOperators returns the operators of the rows, columns and boxes
+// shared by pos1 and pos2.
+func (b *Board) getCommonOperators(pos1 int, pos2 int) []containerOperator {
+	c1 := getCoords(pos1)
+	c2 := getCoords(pos2)
+
+	var list []containerOperator
+	if c1.row == c2.row {
+		list = append(list, b.operateOnRow)
+	}
+	if c1.col == c2.col {
+		list = append(list, b.operateOnColumn)
+	}
+	if c1.box == c2.box {
+		list = append(list, b.operateOnBox)
+	}
+	return list
+}
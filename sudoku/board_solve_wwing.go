@@ -0,0 +1,62 @@
+package sudoku
+
+// SolveWWing looks for two bivalue cells with the same hints x and y which
+// can't see each other, and a strong link on x whose ends each see one of
+// them. One of the cells must be y: if the first isn't, it's x, so the end
+// of the link it sees isn't x, the other end is, and the second cell is y.
+// So y can be removed from the cells seeing both.
+//
+// http://www.sudokuwiki.org/W_Wing_Strategy
+func (b *Board) SolveWWing() error {
+	const technique = "W-WING"
+
+	// the board doesn't change until an elimination, which returns
+	var links [9][]conjugatePair
+	for i := range links {
+		links[i] = b.getConjugatePairs(1 << uint(i))
+	}
+
+	for pos1 := 0; pos1 < 81; pos1++ {
+		blit := b.blits[pos1]
+		if b.solved[pos1] != 0 || GetNumberOfSetBits(blit) != 2 {
+			continue
+		}
+
+		for pos2 := pos1 + 1; pos2 < 81; pos2++ {
+			if b.solved[pos2] != 0 || b.blits[pos2] != blit || canSee(pos1, pos2) {
+				continue
+			}
+
+			for _, x := range GetBitList(blit) {
+				y := blit &^ x
+				for _, link := range links[GetSingleBitValue(x)-1] {
+					end1, end2 := link.cells[0], link.cells[1]
+					if !canSee(pos1, end1) || !canSee(pos2, end2) {
+						end1, end2 = end2, end1
+					}
+					if end1 == pos1 || end1 == pos2 || end2 == pos1 || end2 == pos2 ||
+						!canSee(pos1, end1) || !canSee(pos2, end2) {
+						continue
+					}
+
+					targets := intersect(b.getVisibleCellsWithHint(pos1, y), b.getVisibleCellsWithHint(pos2, y))
+					for _, target := range targets {
+						logEntry, err := b.updateCandidates(target, ^y)
+						if err != nil {
+							return err
+						}
+						if logEntry != nil {
+							b.AddLog(technique, logEntry, "wing1=%v wing2=%v link=%v %v hint %v", pos1, pos2, end1, end2, x)
+						}
+					}
+					if b.changed {
+						// let simpler techniques take over
+						return nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
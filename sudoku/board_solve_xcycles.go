@@ -0,0 +1,258 @@
+package sudoku
+
+import "strings"
+
+// maxXCycleLinks limits the number of strong links in a continuous loop.
+const maxXCycleLinks = 6
+
+type xCycleNode struct {
+	pos int
+	on  bool
+}
+
+func (b *Board) SolveXCycles() error {
+	// http://www.sudokuwiki.org/X_Cycles
+	// http://www.sudokuwiki.org/X_Cycles_Part_2
+	// a loop of cells with the same hint joined by alternating strong links
+	// (the only two cells with the hint in a container, one must be the
+	// value) and weak links (cells which see each other, at most one can
+	// be the value).
+	for v := uint(1); v <= 9; v++ {
+		hint := uint(1 << (v - 1))
+
+		if err := b.xCyclesDiscontinuous(hint); err != nil {
+			return err
+		}
+		if b.changed {
+			// let simpler techniques take over
+			return nil
+		}
+
+		if err := b.xCyclesContinuous(hint); err != nil {
+			return err
+		}
+		if b.changed {
+			return nil
+		}
+	}
+	return nil
+}
+
+// xCyclesDiscontinuous looks for loops which only alternate away from one
+// cell. Rule 2: if two strong links meet at the cell it must be the value,
+// as assuming it isn't leads back to it being the value. Rule 3: if two
+// weak links meet at the cell it can't be the value.
+func (b *Board) xCyclesDiscontinuous(hint uint) error {
+	const technique = "X-CYCLES"
+
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] != 0 || b.blits[pos]&hint == 0 {
+			continue
+		}
+
+		// rule 2, strong links at both ends
+		if chain := b.xCyclesFollow(pos, hint, false); chain != nil {
+			logEntry, err := b.updateCandidates(pos, hint)
+			if err != nil {
+				return err
+			}
+			if logEntry != nil {
+				format, args := xCyclesChainLog(chain, hint)
+				b.AddLog(technique, logEntry, "discontinuous loop "+format+", %v must be %v", append(args, pos, hint)...)
+			}
+			return nil
+		}
+
+		// rule 3, weak links at both ends
+		if chain := b.xCyclesFollow(pos, hint, true); chain != nil {
+			logEntry, err := b.updateCandidates(pos, ^hint)
+			if err != nil {
+				return err
+			}
+			if logEntry != nil {
+				format, args := xCyclesChainLog(chain, hint)
+				b.AddLog(technique, logEntry, "discontinuous loop "+format+", %v can't be %v", append(args, pos, hint)...)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// xCyclesFollow follows the implications of pos being (on) or not being
+// the value, alternating weak links from a cell which is the value and
+// strong links from a cell which isn't. It returns the shortest chain which
+// leads back to pos in the opposite state, or nil.
+func (b *Board) xCyclesFollow(start int, hint uint, on bool) []xCycleNode {
+	// indexed by the state of the cell, 1 if on
+	var parent [81][2]int
+	var visited [81][2]bool
+	visited[start][0], visited[start][1] = true, true
+
+	state := func(on bool) int {
+		if on {
+			return 1
+		}
+		return 0
+	}
+
+	queue := []xCycleNode{{pos: start, on: on}}
+	for len(queue) != 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		var next []int
+		if node.on {
+			next = b.getVisibleCellsWithHint(node.pos, hint)
+		} else {
+			next = b.getStrongLinks(node.pos, hint)
+		}
+
+		for _, pos := range next {
+			if pos == start {
+				if node.on != on {
+					// back in the same state
+					continue
+				}
+
+				// walk back to the start
+				chain := []xCycleNode{{pos: start, on: !on}}
+				for cur := node; ; {
+					chain = append([]xCycleNode{cur}, chain...)
+					if cur.pos == start {
+						break
+					}
+					cur = xCycleNode{pos: parent[cur.pos][state(cur.on)], on: !cur.on}
+				}
+				return chain
+			}
+			if visited[pos][state(!node.on)] {
+				continue
+			}
+			visited[pos][state(!node.on)] = true
+			parent[pos][state(!node.on)] = node.pos
+			queue = append(queue, xCycleNode{pos: pos, on: !node.on})
+		}
+	}
+	return nil
+}
+
+// xCyclesContinuous looks for loops alternating all the way round. Every
+// link then has one end which is the value, so it can be removed from the
+// other cells which see both ends of a link.
+func (b *Board) xCyclesContinuous(hint uint) error {
+	// strong links in both directions
+	var links [][2]int
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] != 0 || b.blits[pos]&hint == 0 {
+			continue
+		}
+		for _, other := range b.getStrongLinks(pos, hint) {
+			links = append(links, [2]int{pos, other})
+		}
+	}
+
+	var used [81]bool
+	var chain []int
+	var follow func(start int) ([]int, error)
+	follow = func(start int) ([]int, error) {
+		last := links[chain[len(chain)-1]]
+		if len(chain) > 1 && canSee(last[1], links[start][0]) {
+			var loop []int
+			for _, i := range chain {
+				loop = append(loop, links[i][0], links[i][1])
+			}
+			updated, err := b.xCyclesContinuousEliminate(loop, hint)
+			if err != nil || updated {
+				return loop, err
+			}
+		}
+		if len(chain) == maxXCycleLinks {
+			return nil, nil
+		}
+
+		// only links after the start, each loop is tried from its first link
+		for i := start + 1; i < len(links); i++ {
+			link := links[i]
+			if used[link[0]] || used[link[1]] || !canSee(last[1], link[0]) {
+				continue
+			}
+
+			used[link[0]], used[link[1]] = true, true
+			chain = append(chain, i)
+			loop, err := follow(start)
+			chain = chain[:len(chain)-1]
+			used[link[0]], used[link[1]] = false, false
+
+			if err != nil || loop != nil {
+				return loop, err
+			}
+		}
+		return nil, nil
+	}
+
+	for start, link := range links {
+		used[link[0]], used[link[1]] = true, true
+		chain = []int{start}
+		loop, err := follow(start)
+		used[link[0]], used[link[1]] = false, false
+		if err != nil {
+			return err
+		}
+		if loop != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// xCyclesContinuousEliminate removes hint from cells outside loop which
+// see both ends of one of its links.
+func (b *Board) xCyclesContinuousEliminate(loop []int, hint uint) (bool, error) {
+	const technique = "X-CYCLES"
+
+	var chain []xCycleNode
+	for i, pos := range loop {
+		chain = append(chain, xCycleNode{pos: pos, on: i%2 == 1})
+	}
+	chain = append(chain, xCycleNode{pos: loop[0], on: false})
+	format, args := xCyclesChainLog(chain, hint)
+
+	updated := false
+	for i := range loop {
+		pos1, pos2 := loop[i], loop[(i+1)%len(loop)]
+		targets := intersect(b.getVisibleCellsWithHint(pos1, hint), b.getVisibleCellsWithHint(pos2, hint))
+		targets = subtract(targets, loop)
+
+		for _, target := range targets {
+			logEntry, err := b.updateCandidates(target, ^hint)
+			if err != nil {
+				return false, err
+			}
+			if logEntry != nil {
+				b.AddLog(technique, logEntry, "continuous loop "+format, args...)
+				updated = true
+			}
+		}
+	}
+	return updated, nil
+}
+
+// xCyclesChainLog returns the log format and arguments of a chain, "="
+// being a strong link and "-" a weak link.
+func xCyclesChainLog(chain []xCycleNode, hint uint) (string, []interface{}) {
+	var format []string
+	var args []interface{}
+	for i, node := range chain {
+		if i != 0 {
+			if node.on {
+				format = append(format, "=")
+			} else {
+				format = append(format, "-")
+			}
+		}
+		format = append(format, "%v")
+		args = append(args, node.pos)
+	}
+	return strings.Join(format, "") + " hint %v", append(args, hint)
+}
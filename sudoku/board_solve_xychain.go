@@ -1,10 +1,10 @@
-package main
+package sudoku
 
 import (
 	"strings"
 )
 
-func (b *board) SolveXYChain() error {
+func (b *Board) SolveXYChain() error {
 	// http://www.sudokuwiki.org/XY_Chains
 	// bi-value cells linked together by one value (and visible to each other)
 	// terminate when more than one away and cell shares a value with the other end
@@ -30,7 +30,7 @@ func (b *board) SolveXYChain() error {
 	return nil
 }
 
-func (b *board) xyChainTestPosition(i int, excludeBit uint) (bool, error) {
+func (b *Board) xyChainTestPosition(i int, excludeBit uint) (bool, error) {
 	const technique = "XY-CHAIN"
 
 	hint := excludeBit
@@ -83,7 +83,7 @@ func (b *board) xyChainTestPosition(i int, excludeBit uint) (bool, error) {
 	return false, nil
 }
 
-func (b *board) xyChainFollow(chain []int, excludeBit uint, firstBitInChain uint, depth int) [][]int {
+func (b *Board) xyChainFollow(chain []int, excludeBit uint, firstBitInChain uint, depth int) [][]int {
 	var lists [][]int
 
 	curPos := chain[len(chain)-1]
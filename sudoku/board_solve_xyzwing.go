@@ -0,0 +1,172 @@
+package sudoku
+
+func (b *Board) SolveXYZWing() error {
+	// http://www.sudokuwiki.org/XYZ_Wing
+	// like a Y-Wing but the hinge has three hints XYZ and the wings XZ and YZ.
+	// one of the three cells must be Z, so Z can be removed from the cells
+	// which can see all three.
+	const technique = "XYZ-WING"
+
+	for i := 0; i < 81; i++ {
+		if b.solved[i] != 0 {
+			continue
+		}
+
+		blit := b.blits[i]
+		if GetNumberOfSetBits(blit) != 3 {
+			continue
+		}
+
+		// wings have two of the hinge's hints
+		var candidates []int
+		for _, item := range b.getVisibleCells(i) {
+			itemBlit := b.blits[item]
+			if GetNumberOfSetBits(itemBlit) == 2 && itemBlit&^blit == 0 {
+				candidates = append(candidates, item)
+			}
+		}
+
+		for _, wings := range getPermutations(2, candidates, []int{}) {
+			wingBlit1 := b.blits[wings[0]]
+			wingBlit2 := b.blits[wings[1]]
+			if wingBlit1 == wingBlit2 {
+				continue
+			}
+
+			removeHint := wingBlit1 & wingBlit2
+			for _, target := range b.getVisibleCellsWithHint(i, removeHint) {
+				if target == wings[0] || target == wings[1] ||
+					!canSee(target, wings[0]) || !canSee(target, wings[1]) {
+					continue
+				}
+
+				logEntry, err := b.updateCandidates(target, ^removeHint)
+				if err != nil {
+					return err
+				}
+
+				if logEntry != nil {
+					b.AddLog(technique, logEntry, "hinge=%v wing1=%v wing2=%v", i, wings[0], wings[1])
+				}
+			}
+
+			if b.changed {
+				// let simpler techniques take over
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *Board) SolveWXYZWing() error {
+	// http://www.sudokuwiki.org/WXYZ_Wing
+	// four cells with four hints between them. a hint is restricted when
+	// all the cells having it can see each other, so it's in one cell at
+	// most. if only one hint Z is not restricted, the other three can't fill
+	// the four cells and one of them must be Z. Z can be removed from the
+	// cells which can see all the cells with Z.
+	const technique = "WXYZ-WING"
+
+	for hints := uint(0xF); hints <= 0x1E0; hints++ {
+		if GetNumberOfSetBits(hints) != 4 {
+			continue
+		}
+
+		var cells []int
+		for i := 0; i < 81; i++ {
+			if b.solved[i] == 0 && b.blits[i]&^hints == 0 {
+				cells = append(cells, i)
+			}
+		}
+		if len(cells) < 4 {
+			continue
+		}
+
+		if err := b.findWXYZWing(technique, hints, cells, nil); err != nil {
+			return err
+		}
+		if b.changed {
+			// let simpler techniques take over
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// findWXYZWing adds cells to wing in order, pruning wings with more than
+// one unrestricted hint, and eliminates once it has four cells.
+func (b *Board) findWXYZWing(technique string, hints uint, cells []int, wing []int) error {
+	unrestricted := b.getUnrestrictedHints(wing)
+	if GetNumberOfSetBits(unrestricted) > 1 {
+		return nil
+	}
+
+	if len(wing) < 4 {
+		for i, pos := range cells {
+			if err := b.findWXYZWing(technique, hints, cells[i+1:], append(wing, pos)); err != nil {
+				return err
+			}
+			if b.changed {
+				return nil
+			}
+		}
+		return nil
+	}
+
+	var union uint
+	for _, pos := range wing {
+		union |= b.blits[pos]
+	}
+	if union != hints || unrestricted == 0 {
+		return nil
+	}
+
+	var zCells []int
+	for _, pos := range wing {
+		if b.blits[pos]&unrestricted != 0 {
+			zCells = append(zCells, pos)
+		}
+	}
+
+targetLoop:
+	for _, target := range b.getVisibleCellsWithHint(zCells[0], unrestricted) {
+		for _, pos := range wing {
+			if target == pos {
+				continue targetLoop
+			}
+		}
+		for _, pos := range zCells[1:] {
+			if !canSee(target, pos) {
+				continue targetLoop
+			}
+		}
+
+		logEntry, err := b.updateCandidates(target, ^unrestricted)
+		if err != nil {
+			return err
+		}
+
+		if logEntry != nil {
+			b.AddLog(technique, logEntry, "cells=%v %v %v %v unrestricted=%v", wing[0], wing[1], wing[2], wing[3], unrestricted)
+		}
+	}
+
+	return nil
+}
+
+// getUnrestrictedHints returns the hints of the cells which are in two cells
+// that can't see each other.
+func (b *Board) getUnrestrictedHints(cells []int) uint {
+	var unrestricted uint
+	for i := 0; i < len(cells); i++ {
+		for j := i + 1; j < len(cells); j++ {
+			if !canSee(cells[i], cells[j]) {
+				unrestricted |= b.blits[cells[i]] & b.blits[cells[j]]
+			}
+		}
+	}
+	return unrestricted
+}
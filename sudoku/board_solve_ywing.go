@@ -1,8 +1,8 @@
-package main
+package sudoku
 
 import "fmt"
 
-func (b *board) SolveYWing() error {
+func (b *Board) SolveYWing() error {
 	// http://www.sudokuwiki.org/Y_Wing_Strategy
 	// start simple..
 	// look for three cells which each have two hints and can 'see' each other,
@@ -0,0 +1,1045 @@
+package sudoku
+
+import (
+	"bufio"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBoardAPI(t *testing.T) {
+	// arrange
+	grid := `1 _ 3 _ _ 6 _ 8 _
+_ 5 _ _ 8 _ 1 2 _
+7 _ 9 1 _ 3 _ 5 6
+_ 3 _ _ 6 7 _ 9 _
+5 _ 7 8 _ _ _ 3 _
+8 _ 1 _ 3 _ 5 _ 7
+_ 4 _ _ 7 8 _ 1 _
+6 _ 8 _ _ 2 _ 4 _
+_ 1 2 _ 4 5 _ 7 8
+`
+
+	b, err := NewBoardFromGrid(grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compact, err := NewBoardFromCompact(b.GetCompact())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// assert initial state
+	if b.Value(0, 0) != 1 || b.Value(0, 1) != 0 {
+		t.Fatalf("expected R0C0=1 R0C1=0, actual R0C0=%d R0C1=%d", b.Value(0, 0), b.Value(0, 1))
+	}
+	if !reflect.DeepEqual(b.Candidates(0, 1), []int{2}) {
+		t.Fatalf("R0C1 expected candidates [2] actual %v", b.Candidates(0, 1))
+	}
+	if !reflect.DeepEqual(b.Candidates(0, 1), compact.Candidates(0, 1)) {
+		t.Fatalf("compact and grid boards differ")
+	}
+
+	// act
+	clone := b.Clone()
+	if err = clone.Solve(); err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+	if !clone.IsSolved() {
+		t.Fatal("clone not solved")
+	}
+	if b.IsSolved() || b.Value(0, 1) != 0 {
+		t.Fatal("original board modified by solving its clone")
+	}
+}
+
+func TestCellName(t *testing.T) {
+	inputs := []int{0, 10, 71, 72, 80}
+	expecteds := []string{"A1", "B2", "H9", "J1", "J9"}
+
+	for i, input := range inputs {
+		if actual := CellName(input); actual != expecteds[i] {
+			t.Fatalf("%d: expected %s actual %s", input, expecteds[i], actual)
+		}
+	}
+}
+
+func TestEmptyRects(t *testing.T) {
+	// arrange
+	board := `400103000080507420900040005139000500270910040804730912592080000748350290000279854`
+
+	b, err := LoadBoard([]byte(board))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//b.PrintHints()
+
+	// check board is in expected initial state
+	testHint(t, b, 3, 8, []uint{6, 7, 8})
+
+	// act
+	b.changed = false
+	if err = b.SolveEmptyRectangles(); err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+	if !b.changed {
+		t.Fatal("board not changed")
+	}
+
+	//b.PrintHints()
+
+	testHint(t, b, 3, 8, []uint{7, 8})
+}
+
+func TestEmptyRects2(t *testing.T) {
+	// arrange
+	hintBoard := `
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+|r,c|               0               1               2 |               3               4               5 |               6               7               8 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 0 |           (2,5)       (1,2,6,7)               8 |               4         (2,5,7)       (2,5,6,9) |       (1,2,6,9)               3           (1,9) |
+| 1 |         (2,4,5)       (1,2,6,7)         (1,4,7) |               3         (2,5,7)       (2,5,6,9) |     (1,2,6,8,9)       (1,6,8,9)         (1,8,9) |
+| 2 |               9         (2,3,6)         (2,3,6) |         (2,6,8)           (2,8)               1 |               5               7               4 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 3 |               7               9       (2,3,5,6) |       (1,2,5,6)     (1,2,3,4,5)               8 |         (1,3,6)         (1,4,6)           (1,3) |
+| 4 |         (2,3,8)       (2,3,6,8)         (2,3,6) |         (1,2,6)       (1,2,3,4)               7 |       (1,3,6,9)       (1,4,6,9)               5 |
+| 5 |               1               4         (3,5,6) |               9           (3,5)         (3,5,6) |           (7,8)               2           (7,8) |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 6 |         (3,4,8)       (1,3,7,8)               9 |       (1,5,7,8)               6         (3,4,5) |       (1,3,7,8)           (1,8)               2 |
+| 7 |               6               5           (1,7) |       (1,2,7,8)       (1,2,3,8)           (2,3) |               4         (1,8,9)     (1,3,7,8,9) |
+| 8 |       (2,3,4,8)     (1,2,3,7,8)         (1,4,7) |         (1,7,8)               9           (3,4) |       (1,3,7,8)               5               6 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+`
+
+	b := loadBoardWithHints(t, hintBoard)
+
+	// check board is in expected initial state
+	testHint(t, b, 4, 1, []uint{2, 3, 6, 8})
+
+	// act
+	b.changed = false
+	if err := b.SolveEmptyRectangles(); err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+	testHint(t, b, 4, 1, []uint{2, 3, 6, 8})
+
+	if b.changed {
+		t.Fatal("board changed, no empty-rectangle options here")
+	}
+}
+
+func TestEmptyRects3(t *testing.T) {
+	// arrange
+	board := `750960320000702050000030047970050083005070100180000075240090710010407000097016030`
+
+	b, err := LoadBoard([]byte(board))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = b.SolveWithSolversList(b.getSimpleSolvers()); err != nil {
+		t.Fatal(err)
+	}
+
+	// check board is in expected initial state
+	testHint(t, b, 4, 8, []uint{2, 4, 6, 9})
+	testHint(t, b, 7, 2, []uint{3, 6, 8})
+
+	// act
+	if err = b.SolveEmptyRectangles(); err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+	if !b.changed {
+		t.Fatal("board not changed")
+	}
+}
+
+func TestXYChain(t *testing.T) {
+	// arrange
+	hintBoard := `
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+|r,c|               0               1               2 |               3               4               5 |               6               7               8 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 0 |               4               8               7 |               3               1               2 |           (5,6)               9           (5,6) |
+| 1 |           (5,9)           (5,9)               3 |               6           (4,8)           (4,8) |               2               7               1 |
+| 2 |               1               2               6 |           (5,7)               9           (5,7) |               3               8               4 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 3 |               7           (3,4)               5 |           (8,9)         (3,4,8)         (4,8,9) |               1               6               2 |
+| 4 |           (6,9)               1           (4,9) |               2         (3,4,6)           (5,7) |               8           (3,4)           (5,7) |
+| 5 |           (2,8)         (3,4,6)           (2,8) |           (5,7)         (3,4,6)               1 |           (5,7)           (3,4)               9 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 6 |           (5,8)           (4,5)               1 |           (4,8)               7               6 |               9               2               3 |
+| 7 |               3           (6,7)           (8,9) |               1               2           (8,9) |               4               5           (6,7) |
+| 8 |           (2,6)       (4,6,7,9)         (2,4,9) |           (4,9)               5               3 |           (6,7)               1               8 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+	`
+	b := loadBoardWithHints(t, hintBoard)
+
+	// check board is in expected initial state
+	testHint(t, b, 5, 6, []uint{5, 7})
+	testHint(t, b, 3, 1, []uint{3, 4})
+	testHint(t, b, 5, 1, []uint{3, 4, 6})
+	testHint(t, b, 8, 2, []uint{2, 4, 9})
+
+	// act
+	if err := b.SolveXYChain(); err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+
+	// test for absence of defect in XY-Chain:
+	// - R5C6: old hints: 5,7        remove hint: 5 remaining hints: 7
+	testHint(t, b, 5, 6, []uint{5, 7})
+
+	// test for expected state after XY-Chain applied
+	// note: this test may fail in the future if XY-Chain is modified
+	//       since it could pick a different chain to operate on
+	// - R3C1: old hints: 3,4        remove hint: 4 remaining hints: 3
+	// - R5C1: old hints: 3,4,6      remove hint: 4 remaining hints: 3,6
+	// - R8C2: old hints: 2,4,9      remove hint: 4 remaining hints: 2,9
+	testHint(t, b, 3, 1, []uint{3})
+	testHint(t, b, 5, 1, []uint{3, 6})
+	testHint(t, b, 8, 2, []uint{2, 9})
+}
+
+func testHint(t *testing.T, b *Board, row, col int, hints []uint) {
+	actual := b.blits[row*9+col]
+	var expected uint
+	for _, hint := range hints {
+		expected |= 1 << (hint - 1)
+	}
+	if expected != actual {
+		t.Fatalf("R%dC%d, expected %v actual %v", row, col, hints, GetBitsString(actual))
+	}
+}
+
+func loadBoardWithHints(t *testing.T, hintBoard string) (b *Board) {
+	// read the text board, apply hints
+	var err error
+	sr := strings.NewReader(hintBoard)
+	r := bufio.NewReader(sr)
+
+	// skip header
+	for i := 0; i < 4; i++ {
+		if _, err = r.ReadString('\n'); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b = &Board{}
+	var line string
+	for i := 0; i < 9; i++ {
+		if line, err = r.ReadString('\n'); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.HasPrefix(line, "|---|") {
+			if line, err = r.ReadString('\n'); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		line = strings.Replace(line, "\r", "", -1)
+		line = strings.Replace(line, "\n", "", -1)
+		line = line[6 : len(line)-2]
+		line = strings.Replace(line, " |", "", -1)
+
+		start := 0
+		cells := make([]string, 9)
+		for j := 0; j < 9; j++ {
+			end := start + 15
+			cell := strings.Trim(line[start:end], " ")
+			cells[j] = cell
+			start = end + 1
+
+			pos := i*9 + j
+			if strings.HasPrefix(cell, "(") {
+				// get hints
+				hints := strings.Split(cell[1:len(cell)-1], ",")
+				for _, hint := range hints {
+					val, err := strconv.Atoi(hint)
+					if err != nil {
+						t.Fatal(err)
+					}
+					b.blits[pos] |= 1 << uint(val-1)
+				}
+			} else {
+				// solved cell
+				val, err := strconv.Atoi(cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b.solved[pos] = uint(val)
+				b.blits[pos] = 1 << uint(val-1)
+			}
+		}
+	}
+
+	return b
+}
+
+var testBoardFiles = []string{
+	"../test_files/input.txt",
+	"../test_files/01_naked_single_493382.txt",
+	"../test_files/02_hidden_single_1053217.txt",
+	"../test_files/03_naked_pair_1053222.txt",
+	"../test_files/04_naked_triple_1043003.txt",
+	"../test_files/05_naked_quint_1051073.txt",
+	"../test_files/06_hidden_pair_1208057.txt",
+	"../test_files/07_hidden_triple_188899.txt",
+	"../test_files/08_hidden_quint_188899.txt",
+	"../test_files/09_pointing_pair_and_triple_1011509.txt",
+	"../test_files/10_xwing_1307267.txt",
+	"../test_files/12_tough_20151107_173.txt",
+	"../test_files/11_swordfish_1280430.txt",
+	"../test_files/13_swordfish_008009000300057001000100009230000070005406100060000038900003000700840003000700600.txt",
+	"../test_files/14_swordfish_980010020002700000000009010700040800600107002009030005040900000000005700070020039.txt",
+	"../test_files/15_swordfish_108000067000050000000000030006100040450000900000093000200040010003002700807001005.txt",
+	"../test_files/16_swordfish_107300040800006000050870630090000510000000007700060080000904000080100002410000000.txt",
+	"../test_files/17_swordfish_300040000000007048000000907010003080400050020050008070500300000000000090609025300.txt",
+	"../test_files/18_swordfish.txt",
+	"../test_files/19_supposedly_hard.txt",
+	"../test_files/20_17_clues.txt",
+	"../test_files/21_ywing.txt",
+	"../test_files/22_xychain.txt",
+	"../test_files/23_xychain.txt",
+	"../test_files/24_xychain.txt",
+	"../test_files/25_xychain.txt",
+	"../test_files/26_xychain.txt",
+	"../test_files/27_xcycles.txt",
+	"../test_files/28_xcycles.txt",
+	"../test_files/29_ben.txt",
+	"../test_files/30_starburst_leo.txt",
+}
+
+func TestBoards(t *testing.T) {
+	files := testBoardFiles
+
+	for _, file := range files {
+		board, err := LoadBoardFile(file)
+		if err != nil {
+			t.Fatalf("%s: %s", file, err)
+			return
+		}
+
+		if err = board.Solve(); err != nil {
+			board.PrintHints()
+			t.Fatalf("%s: %s", file, err)
+			return
+		}
+
+		if !board.IsSolved() {
+			board.PrintHints()
+			board.PrintCompact()
+			t.Fatalf("%s: could not solve", file)
+			return
+		}
+	}
+	fmt.Printf("solved %d puzzles\n", len(files))
+}
+
+func TestBoardsConcurrent(t *testing.T) {
+	// solving must not share state between boards; run with -race
+	var wg sync.WaitGroup
+	errs := make([]error, len(testBoardFiles))
+	for i, file := range testBoardFiles {
+		wg.Add(1)
+		go func(i int, file string) {
+			defer wg.Done()
+
+			board, err := LoadBoardFile(file)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if err = board.Solve(); err != nil {
+				errs[i] = err
+				return
+			}
+			if !board.IsSolved() {
+				errs[i] = fmt.Errorf("could not solve")
+			}
+		}(i, file)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("%s: %s", testBoardFiles[i], err)
+		}
+	}
+}
+
+func getKnownAnswer(t *testing.T, answer string) *[81]byte {
+	if len(answer) != 81 {
+		t.Errorf("len(answer) == %d, expected 81", len(answer))
+		return nil
+	}
+
+	var ka [81]byte
+	for i := 0; i < 81; i++ {
+		ka[i] = answer[i] - 48
+	}
+	return &ka
+}
+
+func Test13329(t *testing.T) {
+	// arrange
+	hintBoard := `
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+|r,c|               0               1               2 |               3               4               5 |               6               7               8 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 0 |       (1,5,8,9)         (1,7,9)               2 |               6       (4,5,8,9)     (1,4,5,8,9) |       (5,7,8,9)               3     (1,4,5,8,9) |
+| 1 |               4       (1,3,6,9)     (1,5,6,8,9) |       (1,2,5,9)               7     (1,3,5,8,9) |     (2,5,6,8,9)     (1,2,5,8,9)   (1,2,5,6,8,9) |
+| 2 |   (1,3,5,6,8,9)     (1,3,6,7,9)   (1,5,6,7,8,9) |     (1,2,4,5,9)   (2,3,4,5,8,9)   (1,3,4,5,8,9) |   (2,5,6,7,8,9) (1,2,4,5,7,8,9) (1,2,4,5,6,8,9) |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 3 |       (2,5,6,9)         (2,6,9)         (5,6,9) |         (2,5,9)               1     (3,5,6,8,9) |               4       (2,5,8,9)               7 |
+| 4 |     (1,2,5,6,9)               8               3 |     (2,4,5,7,9)     (2,4,5,6,9)     (4,5,6,7,9) |       (2,5,6,9)         (2,5,9)       (2,5,6,9) |
+| 5 |       (2,5,6,9)     (2,4,6,7,9)     (4,5,6,7,9) |     (2,4,5,7,9) (2,3,4,5,6,8,9) (3,4,5,6,7,8,9) |               1       (2,5,8,9)   (2,3,5,6,8,9) |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 6 |         (1,8,9)         (1,4,9)       (1,4,8,9) |               3         (4,5,9)               2 |       (5,7,8,9)               6     (1,4,5,8,9) |
+| 7 |               7               5     (1,4,6,8,9) |         (1,4,9)         (4,6,9)       (1,4,6,9) |       (2,3,8,9)     (1,2,4,8,9)   (1,2,3,4,8,9) |
+| 8 |     (1,2,3,6,9)   (1,2,3,4,6,9)       (1,4,6,9) |               8       (4,5,6,9)   (1,4,5,6,7,9) |     (2,3,5,7,9)   (1,2,4,5,7,9)   (1,2,3,4,5,9) |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+`
+
+	b := loadBoardWithHints(t, hintBoard)
+	//b.PrintURL()
+	//b.knownAnswer = getKnownAnswer(t, "872691534431275896695438271269513487183724659547986123914352768758169342326847915")
+
+	/*
+	   872 691 534
+	   431 275 896
+	   695 438 271
+
+	   269 513 487
+	   183 724 659
+	   547 986 123
+
+	   914 352 768
+	   758 169 342
+	   326 847 915
+	*/
+
+	//b.quiet = true
+	//b.verbose = true
+
+	// check board is in expected initial state
+	//testHint(t, b, 5, 6, []uint{5, 7})
+	//testHint(t, b, 3, 1, []uint{3, 4})
+	//testHint(t, b, 5, 1, []uint{3, 4, 6})
+	//testHint(t, b, 8, 2, []uint{2, 4, 9})
+
+	// act / assert
+	if err := b.Solve(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestXWing(t *testing.T) {
+	// arrange
+	hintBoard := `
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+|r,c|               0               1               2 |               3               4               5 |               6               7               8 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 0 |               8               7               2 |               6           (4,9)         (1,5,9) |           (5,9)               3       (1,4,5,9) |
+| 1 |               4               3           (1,5) |           (1,2)               7       (1,5,8,9) |           (2,8)         (1,5,9)               6 |
+| 2 |               6               9           (1,5) |         (1,2,4)               3         (1,5,8) |           (2,8)               7         (1,4,5) |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 3 |               2               6               9 |               5               1               3 |               4               8               7 |
+| 4 |               1               8               3 |               7               2               4 |               6           (5,9)           (5,9) |
+| 5 |               5               4               7 |               9               8               6 |               1               2               3 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| 6 |               9               1               4 |               3               5               2 |               7               6               8 |
+| 7 |               7               5               8 |           (1,4)               6           (1,9) |               3           (4,9)               2 |
+| 8 |               3               2               6 |               8           (4,9)               7 |           (5,9)       (1,4,5,9)         (1,5,9) |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+	`
+	b := loadBoardWithHints(t, hintBoard)
+	//b.PrintHints()
+	//b.PrintURL()
+	//b.knownAnswer = getKnownAnswer(t, "872691534431275896695438271269513487183724659547986123914352768758169342326847915")
+
+	/*
+	   872 691 534
+	   431 275 896
+	   695 438 271
+
+	   269 513 487
+	   183 724 659
+	   547 986 123
+
+	   914 352 768
+	   758 169 342
+	   326 847 915
+	*/
+
+	//b.quiet = true
+	//b.verbose = true
+
+	// act / assert
+	if err := b.SolveXWing(); err != nil {
+		b.PrintHints()
+		t.Fatal(err)
+	}
+
+	// try it again
+	if err := b.SolveXWing(); err != nil {
+		b.PrintHints()
+		t.Fatal(err)
+	}
+}
+
+func (b *Board) getSimpleSolvers() []solver {
+	solvers := []solver{
+		{name: "NAKED SINGLE", run: b.SolveNakedSingle},
+		{name: "HIDDEN SINGLE", run: b.SolveHiddenSingle},
+		{name: "NAKED PAIR", run: b.getSolverN(b.SolveNakedN, 2)},
+		{name: "NAKED TRIPLE", run: b.getSolverN(b.SolveNakedN, 3)},
+		{name: "NAKED QUAD", run: b.getSolverN(b.SolveNakedN, 4)},
+		{name: "NAKED QUINT", run: b.getSolverN(b.SolveNakedN, 5)},
+		{name: "HIDDEN PAIR", run: b.getSolverN(b.SolveHiddenN, 2)},
+		{name: "HIDDEN TRIPLE", run: b.getSolverN(b.SolveHiddenN, 3)},
+		{name: "HIDDEN QUAD", run: b.getSolverN(b.SolveHiddenN, 4)},
+		{name: "HIDDEN QUINT", run: b.getSolverN(b.SolveHiddenN, 5)},
+		{name: "POINTING PAIR AND TRIPLE REDUCTION", run: b.SolvePointingPairAndTripleReduction},
+		{name: "BOX LINE", run: b.SolveBoxLine},
+	}
+
+	return solvers
+}
+
+func TestTrace(t *testing.T) {
+	// arrange
+	b, err := LoadBoardFile("../test_files/21_ywing.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetRecordSteps(true)
+
+	// act
+	if err = b.Solve(); err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+	trace := b.Trace()
+	if trace == nil || len(trace.Steps) == 0 {
+		t.Fatal("expected steps to be recorded")
+	}
+
+	// replaying the trace from the start must reach the solved board
+	solved := trace.StartValues
+	blits := trace.StartCandidates
+	for _, step := range trace.Steps {
+		if step.Technique == "" || step.Description == "" {
+			t.Fatalf("step missing technique or description: %#v", step)
+		}
+		for _, placement := range step.Placements {
+			solved[placement.Pos] = uint(placement.Value)
+			blits[placement.Pos] = 1 << uint(placement.Value-1)
+		}
+		for _, elimination := range step.Eliminations {
+			if blits[elimination.Pos] != elimination.OldMask {
+				t.Fatalf("%s: %s expected old mask %s actual %s", step.Technique, getCoords(elimination.Pos),
+					GetBitsString(elimination.OldMask), GetBitsString(blits[elimination.Pos]))
+			}
+			blits[elimination.Pos] = elimination.NewMask
+		}
+	}
+
+	if solved != b.solved {
+		t.Fatalf("replayed trace does not match solution")
+	}
+
+	found := false
+	for _, technique := range trace.Techniques() {
+		if technique == "Y-WING" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected Y-WING in techniques, actual: %v", trace.Techniques())
+	}
+}
+
+func TestTraceClone(t *testing.T) {
+	// arrange, with room to append so a shared backing array would show
+	step := Step{Cells: append(make([]int, 0, 4), 10), Digits: append(make([]int, 0, 4), 5, 9)}
+	b := &Board{recordSteps: true, trace: &Trace{Steps: []Step{step}}}
+
+	// act
+	clone := b.Clone()
+	b.addLogPlacement(20, 1)
+
+	// assert
+	actual := clone.Trace().Steps[0]
+	if !reflect.DeepEqual(actual.Cells, []int{10}) || !reflect.DeepEqual(actual.Digits, []int{5, 9}) {
+		t.Fatalf("clone step modified by the original, cells %v digits %v", actual.Cells, actual.Digits)
+	}
+}
+
+func TestHasUniqueSolution(t *testing.T) {
+	inputs := []string{
+		"487300090000600271126090384705000162000200800000000009001076923300100450000053018",
+		// 21_ywing.txt with A1 removed still has a single solution
+		"087300090000600271126090384705000162000200800000000009001076923300100450000053018",
+		// 21_ywing.txt with A1 and A2 removed has two solutions
+		"007300090000600271126090384705000162000200800000000009001076923300100450000053018",
+		// only 8 clues
+		"123456780000000000000000000000000000000000000000000000000000000000000000000000000",
+	}
+	expecteds := []bool{true, true, false, false}
+
+	for i, input := range inputs {
+		b, err := LoadBoard([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := b.HasUniqueSolution()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if actual != expecteds[i] {
+			t.Fatalf("%s: expected unique=%t actual=%t", input, expecteds[i], actual)
+		}
+		if b.IsSolved() {
+			t.Fatalf("%s: board modified", input)
+		}
+	}
+}
+
+func TestSolutions(t *testing.T) {
+	inputs := []string{
+		"487300090000600271126090384705000162000200800000000009001076923300100450000053018",
+		// 21_ywing.txt with A1 and A2 removed has 13 solutions
+		"007300090000600271126090384705000162000200800000000009001076923300100450000053018",
+		"007300090000600271126090384705000162000200800000000009001076923300100450000053018",
+	}
+	maxes := []int{2, 20, 5}
+	expecteds := []int{1, 13, 5}
+
+	for i, input := range inputs {
+		b, err := LoadBoard([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		list, err := b.Solutions(maxes[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != expecteds[i] {
+			t.Fatalf("%s: expected %d solutions actual %d", input, expecteds[i], len(list))
+		}
+
+		seen := make(map[string]interface{})
+		for _, solution := range list {
+			if _, ok := seen[solution]; ok {
+				t.Fatalf("%s: duplicate solution %s", input, solution)
+			}
+			seen[solution] = struct{}{}
+
+			for pos := 0; pos < 81; pos++ {
+				if input[pos] != '0' && input[pos] != solution[pos] {
+					t.Fatalf("%s: solution %s doesn't keep the clues", input, solution)
+				}
+			}
+		}
+	}
+}
+
+// loadSimpleSolved loads puzzle and applies the simple solvers, giving
+// a fixed position to apply a technique to.
+func loadSimpleSolved(t *testing.T, puzzle string) *Board {
+	b, err := LoadBoard([]byte(puzzle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetRecordSteps(true)
+	if err = b.SolveWithSolversList(b.getSimpleSolvers()); err != nil {
+		t.Fatalf("%s: %s", puzzle, err)
+	}
+	if b.IsSolved() {
+		t.Fatalf("%s: solved by the simple solvers", puzzle)
+	}
+	return b
+}
+
+// testEliminations applies run to b once and asserts it removed exactly
+// the hints listed by cell name, e.g. {"A1": {3, 5}}, and nothing else.
+// It returns the steps run recorded.
+func testEliminations(t *testing.T, b *Board, run func() error, removed map[string][]uint) []Step {
+	var expected [81]uint
+	found := 0
+	for pos := 0; pos < 81; pos++ {
+		if hints, ok := removed[getCoords(pos).String()]; ok {
+			found++
+			for _, hint := range hints {
+				expected[pos] |= 1 << (hint - 1)
+			}
+		}
+	}
+	if found != len(removed) {
+		t.Fatalf("unknown cell in %v", removed)
+	}
+
+	if b.trace == nil {
+		b.SetRecordSteps(true)
+		b.trace = newTrace(b)
+	}
+	n := len(b.Trace().Steps)
+	before := b.blits
+	b.changed = false
+	if err := run(); err != nil {
+		t.Fatal(err)
+	}
+
+	var expectedList, actualList []string
+	for pos := 0; pos < 81; pos++ {
+		if expected[pos] != 0 {
+			expectedList = append(expectedList, getCoords(pos).String()+"="+GetBitsString(expected[pos]))
+		}
+		if actual := before[pos] &^ b.blits[pos]; actual != 0 {
+			actualList = append(actualList, getCoords(pos).String()+"="+GetBitsString(actual))
+		}
+	}
+	if !reflect.DeepEqual(expectedList, actualList) {
+		t.Fatalf("expected removed %v actual %v", expectedList, actualList)
+	}
+	return b.Trace().Steps[n:]
+}
+
+func TestXCycles(t *testing.T) {
+	// 28_xcycles.txt, discontinuous loop with weak links at F1 on 1 (rule 3)
+	b := loadSimpleSolved(t, "804537000023614085605982034000105870500708306080203450200859003050371208008426507")
+	testEliminations(t, b, b.SolveXCycles, map[string][]uint{"F1": {1}})
+
+	// big5.txt, discontinuous loop with strong links at A3 on 6 (rule 2)
+	b = loadSimpleSolved(t, "000080000001030450070005000005001009000523000740008000000006004960070008410900000")
+	testEliminations(t, b, b.SolveXCycles, map[string][]uint{"A3": {4, 9}})
+
+	// 27_xcycles.txt, continuous loop C2=G2-H3=H9-J7=C7-C2 on 8 (rule 1)
+	b = loadSimpleSolved(t, "020000670000070010000904000200500007130080096800003004000009000050010000097000030")
+	testEliminations(t, b, func() error { return b.xCyclesContinuous(1 << 7) },
+		map[string][]uint{"C3": {8}, "C9": {8}, "G3": {8}, "G9": {8}})
+}
+
+func TestUniqueRectangles(t *testing.T) {
+	// big5.txt
+	tests := []struct {
+		puzzle  string
+		run     func(*Board) error
+		prefix  string
+		removed map[string][]uint
+	}{
+		{"000000000040700089590000230070380090200070000306020008000000000650004000904003006", (*Board).SolveUniqueRectangles,
+			"type 1:", map[string][]uint{"J7": {5, 7}}},
+		{"000045002002080516008000700000000159100060000003020080030000900005010000000003041", (*Board).SolveUniqueRectangles,
+			"type 2:", map[string][]uint{"E2": {5}, "F4": {5}}},
+		{"000000560000578009000090000700000850900030700300120000070409028000000490010002000", (*Board).SolveUniqueRectangles,
+			"type 3:", map[string][]uint{"H1": {6}}},
+		{"000403000064000510007080000021908000006000035900040000000010080002007060000309001", (*Board).SolveUniqueRectangles,
+			"type 4:", map[string][]uint{"A2": {9}, "G2": {9}}},
+		{"000003084000050002000824563070040001100000900620700005700900800000000000003000007", (*Board).SolveUniqueRectangles,
+			"type 6:", map[string][]uint{"D3": {9}, "F6": {9}}},
+		{"000000000079006001000003520040070312000300005160080007000100000800502400004030000", (*Board).SolveHiddenUniqueRectangles,
+			"deadly pattern", map[string][]uint{"A9": {6}}},
+	}
+
+	for _, test := range tests {
+		b := loadSimpleSolved(t, test.puzzle)
+		run := func() error { return test.run(b) }
+
+		// skipped unless the solution is assumed unique
+		testEliminations(t, b, run, nil)
+
+		b.SetAssumeUnique(true)
+		steps := testEliminations(t, b, run, test.removed)
+		if !strings.HasPrefix(steps[0].Description, test.prefix) {
+			t.Fatalf("%s: expected %q step, actual: %s", test.puzzle, test.prefix, steps[0].Description)
+		}
+	}
+
+	// top100.txt 320600000006000000040070810000005000090010070000400000085040090000000300000007042,
+	// type 5 with three roof corners D5 D8 F8 having the extra 2
+	hintBoard := `
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+|r,c|               1               2               3 |               4               5               6 |               7               8               9 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| A |               3               2           (1,8) |               6           (8,9)         (1,4,8) |         (4,7,9)               5         (4,7,9) |
+| B |         (1,7,8)           (1,7)               6 |         (1,5,8)         (5,8,9)           (1,4) |           (2,9)           (2,3)         (3,4,9) |
+| C |               5               4               9 |           (2,3)               7           (2,3) |               8               1               6 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| D |       (1,4,6,8)           (1,3)     (1,2,3,4,8) |               7         (2,3,6)               5 |       (1,2,4,9)         (2,3,6)     (1,3,4,8,9) |
+| E |           (4,6)               9         (2,3,4) |           (2,8)               1         (2,6,8) |           (4,5)               7         (3,4,5) |
+| F |       (1,6,7,8)               5     (1,2,3,7,8) |               4           (3,6)           (6,9) |           (1,2)         (2,3,6)         (1,3,8) |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+| G |               2               8               5 |           (1,3)               4         (1,3,6) |         (1,6,7)               9           (1,7) |
+| H |       (1,4,7,9)         (1,6,7)         (1,4,7) |           (5,9)           (2,6)         (1,2,6) |               3               8           (1,5) |
+| J |           (1,9)         (1,3,6)           (1,3) |         (5,8,9)         (5,6,8)               7 |         (1,5,6)               4               2 |
+|---|-------------------------------------------------|-------------------------------------------------|-------------------------------------------------|
+	`
+	b := loadBoardWithHints(t, hintBoard)
+	testEliminations(t, b, b.SolveUniqueRectangles, nil)
+	b.SetAssumeUnique(true)
+	steps := testEliminations(t, b, b.SolveUniqueRectangles, map[string][]uint{"D7": {2}})
+	if !strings.HasPrefix(steps[0].Description, "type 5:") {
+		t.Fatalf("expected type 5 step, actual: %s", steps[0].Description)
+	}
+}
+
+func TestXYZWing(t *testing.T) {
+	// big5.txt, hinge B3 and wings A3 B6 on 6
+	b := loadSimpleSolved(t, "000000000000000041005408020607800000930200005840910060004170300700009000020000000")
+	testEliminations(t, b, b.SolveXYZWing, map[string][]uint{"B2": {6}})
+
+	// big5.txt, hinge F6 and wings D6 F9 on 8
+	b = loadSimpleSolved(t, "000000003054901060000005000026000010890064000700000000000100200000020079000587140")
+	testEliminations(t, b, b.SolveXYZWing, map[string][]uint{"F4": {8}})
+}
+
+func TestWXYZWing(t *testing.T) {
+	// big5.txt, D1 D3 D7 E2 with 1 unrestricted
+	b := loadSimpleSolved(t, "000000000000401020000080419080000092700025000400000006006000003231000007004007650")
+	testEliminations(t, b, b.SolveWXYZWing, map[string][]uint{"E7": {1}})
+
+	// big5.txt, A6 A7 G4 G7 with 3 unrestricted
+	b = loadSimpleSolved(t, "000000000050000006900720001010540030007900640000600702081000004200080000060000508")
+	testEliminations(t, b, b.SolveWXYZWing, map[string][]uint{"A4": {3}, "B4": {3}, "G6": {3}, "J6": {3}})
+}
+
+func TestJellyfish(t *testing.T) {
+	// big5.txt, on 7
+	b := loadSimpleSolved(t, "000005013050019807006004000072090040000053100000000000000028000600000030420060500")
+	testEliminations(t, b, b.SolveJellyfish, map[string][]uint{"F4": {7}, "F8": {7}, "J8": {7}})
+
+	// big5.txt, on 9
+	b = loadSimpleSolved(t, "000300070850000001030004000070048000028070030106500000000000700004601000000093210")
+	testEliminations(t, b, b.SolveJellyfish, map[string][]uint{"A9": {9}, "D1": {9}, "D8": {9}, "D9": {9}, "F8": {9}})
+}
+
+func TestFinnedFish(t *testing.T) {
+	// big5.txt
+	tests := []struct {
+		puzzle  string
+		run     func(*Board) error
+		removed map[string][]uint
+	}{
+		// base rows C,F cover columns 2,4 with fin C6 on 2
+		{"000000000017008000000070035009000517008100002003067000002001800000600400080305090", (*Board).SolveFinnedXWing,
+			map[string][]uint{"A4": {2}, "B4": {2}}},
+		// base columns 1,7 cover rows E,H with fin J7 on 2
+		{"000000005020007000050610704000002000009406008100000570800000001000905430900360000", (*Board).SolveSashimiXWing,
+			map[string][]uint{"H9": {2}}},
+		// base columns 1,3,4 cover rows A,F,J with fin C4 on 8
+		{"000000000300507809704006502005100007000042900002000006006958000000060080000003000", (*Board).SolveFinnedSwordFish,
+			map[string][]uint{"A5": {8}}},
+		// base rows D,F,G cover columns 1,4,9 with fin G5 on 5
+		{"000000006000020040006005730600000300170800094000790000030100060000407520800000900", (*Board).SolveSashimiSwordFish,
+			map[string][]uint{"J4": {5}}},
+		// base rows A,D,G,H cover columns 1,2,4,5 with fin A3 on 5
+		{"000000010008300006400080005000007000030800607007000950003400000009602708002090040", (*Board).SolveFinnedJellyfish,
+			map[string][]uint{"B1": {5}, "B2": {5}}},
+		// base rows B,C,E,J cover columns 1,5,7,9 with fin E2 on 4
+		{"000000108060007050090058000000000240602005000035070000004700002850400096000003000", (*Board).SolveSashimiJellyfish,
+			map[string][]uint{"F1": {4}}},
+	}
+
+	for _, test := range tests {
+		b := loadSimpleSolved(t, test.puzzle)
+		testEliminations(t, b, func() error { return test.run(b) }, test.removed)
+	}
+}
+
+func TestAIC(t *testing.T) {
+	// sudoku17.txt, grouped node F2+F3 on 3
+	b := loadSimpleSolved(t, "000000091700000030000200000090010080005000600200000000080090000000600400000700200")
+	testEliminations(t, b, b.SolveAIC, map[string][]uint{"E1": {3}})
+
+	// big5.txt, through the bivalue cells A3 and B2
+	b = loadSimpleSolved(t, "100034006000000003006500107800710000000600020702040000300005000040020900000360001")
+	testEliminations(t, b, b.SolveAIC, map[string][]uint{"B1": {5}})
+
+	// big5.txt, discontinuous loop on A2: 1 is false with weak links at both ends
+	b = loadSimpleSolved(t, "000008006400301000020400800000090050005002600002000007050207010030000000807064500")
+	testEliminations(t, b, b.SolveAIC, map[string][]uint{"A2": {1}})
+
+	// discontinuous loops on A1: 9 is true with strong links at both ends,
+	// so 6 is false with weak links at both ends
+	b = loadSimpleSolved(t, "073800125281035604504021380325408710710350248408172503030287451157000832842513900")
+
+	g := b.getAICGraph()
+	start := g.single[0][8]
+	found := false
+	g.follow(aicState{node: start, on: false}, func(chain []aicState) bool {
+		last := chain[len(chain)-1]
+		found = last.node == start && last.on
+		return found
+	})
+	if !found {
+		t.Fatal("expected a loop with strong links at both ends on A1 9")
+	}
+
+	testEliminations(t, b, b.SolveAIC, map[string][]uint{"A1": {6}})
+}
+
+func TestForcingChains(t *testing.T) {
+	tests := []struct {
+		puzzle        string
+		run           func(*Board) error
+		removed       map[string][]uint
+		contradiction bool
+	}{
+		// big5.txt, A3=7 and A3=8 both remove 7 from A8
+		{"000060200109000000040003050300059010002010006006408900073084000060000000005000400", (*Board).SolveCellForcingChains, map[string][]uint{"A8": {7}}, false},
+		// top95.txt, 4 in A3 and in A8 both remove 4 from G3
+		{"600302000050000010000000000702600000000000054300000000080150000000040200000000700", (*Board).SolveUnitForcingChains, map[string][]uint{"G3": {4}}, false},
+		// top100.txt, A2=3 and A2<>3 both remove 3 from A4
+		{"802000004090000007005001390080017000000502001000008036007100000400070000320005000", (*Board).SolveDigitForcingChains, map[string][]uint{"A4": {3}}, false},
+		// sudoku17.txt, A1=7 empties A1
+		{"000000410200300000600000000000065040380000000020004000000500302001070000000000000", (*Board).SolveCellForcingChains, map[string][]uint{"A1": {7}}, true},
+		// sudokus.txt, B5<>6 leads to a contradiction so B5 is 6
+		{"307040008000500900008003050001000060006409100020000700090700500005001000100090402", (*Board).SolveDigitForcingChains, map[string][]uint{"B5": {8}}, true},
+	}
+
+	for _, test := range tests {
+		b := loadSimpleSolved(t, test.puzzle)
+		steps := testEliminations(t, b, func() error { return test.run(b) }, test.removed)
+		if len(steps) != 1 {
+			t.Fatalf("expected 1 step, got %d", len(steps))
+		}
+		if contradiction := strings.Contains(steps[0].Description, "contradiction"); contradiction != test.contradiction {
+			t.Fatalf("expected contradiction %t, got %q", test.contradiction, steps[0].Description)
+		}
+	}
+}
+
+func TestMedusa(t *testing.T) {
+	tests := []struct {
+		puzzle  string
+		run     func(*Board) error
+		reason  string
+		removed map[string][]uint
+	}{
+		// big5.txt, rule 1
+		{"036000720012800906470000000000005800080020040090300000900701000000000000000062015", (*Board).SolveMedusa,
+			"color 1 is false: twice in F7", map[string][]uint{"C7": {1}, "C8": {5}, "C9": {8}, "F7": {2, 5}, "F9": {1}, "G8": {8}, "G9": {2}}},
+		// top100.txt, rule 2
+		{"070000000600540003001002400007008000000290000049003008003000700200734009000000060", (*Board).SolveMedusa,
+			"color 2 is false: 7 twice in a house", map[string][]uint{"B8": {7}, "C5": {7}, "E6": {7}, "F8": {7}}},
+		// sudokus.txt, rule 3
+		{"504100090000620500900005000800000031206000709730000002000400005002063000010002308", (*Board).SolveMedusa,
+			"F6 has both colors", map[string][]uint{"F6": {8}}},
+		// big5.txt, rule 4
+		{"000000000000000041005408020607800000930200005840910060004170300700009000020000000", (*Board).SolveMedusa,
+			"G6=6 sees both colors", map[string][]uint{"G6": {6}}},
+		// big5.txt, rule 5
+		{"000851200089000460010000500000603000300000020005090600004000000000109070000325006", (*Board).SolveMedusa,
+			"J3=1 sees the other color of its cell", map[string][]uint{"J3": {1}}},
+		// big5.txt, rule 6
+		{"000100700014200650200005000060030800008001020000409000602000900800703060030000000", (*Board).SolveMedusa,
+			"color 1 is false: E9 emptied", map[string][]uint{"A1": {5}, "A2": {9}, "E1": {9}, "E2": {5}}},
+		// big5.txt, a color of one cluster sees both colors of the other
+		{"000006000200000090940000213000007000000400580180090007000002800015040030002063000", (*Board).SolveMultiColoring,
+			"color 1 is false: D2 sees both colors of", map[string][]uint{"D2": {2}}},
+		// big5.txt, a color of each cluster see each other
+		{"000000000000062000000809037020017009490206000700000000510000380008900705000000102", (*Board).SolveMultiColoring,
+			"colors 2 and 2 see each other", map[string][]uint{"A3": {3}}},
+	}
+
+	for _, test := range tests {
+		b := loadSimpleSolved(t, test.puzzle)
+		steps := testEliminations(t, b, func() error { return test.run(b) }, test.removed)
+		for _, step := range steps {
+			if !strings.Contains(step.Description, test.reason) {
+				t.Fatalf("%s: expected %q step, actual: %s", test.puzzle, test.reason, step.Description)
+			}
+		}
+	}
+}
+
+func TestALS(t *testing.T) {
+	tests := []struct {
+		puzzle  string
+		run     func(*Board) error
+		prefix  string
+		removed map[string][]uint
+	}{
+		// big5.txt
+		{"000000000000062000000809037020017009490206000700000000510000380008900705000000102", (*Board).SolveALSXZ,
+			"singly linked", map[string][]uint{"D7": {8}}},
+		// top95.txt, 3 and 6 are removed from row D and 4 and 7 from the second ALS's box
+		{"002470058000000000000001040000020009528090400009000100000000030300007500685002000", (*Board).SolveALSXZ,
+			"doubly linked", map[string][]uint{"D4": {3, 6}, "D6": {3, 6}, "F2": {4, 7}}},
+		// big5.txt
+		{"000000090170003006006000302080001007000090050200074160098007000030000005000410000", (*Board).SolveALSXYWing,
+			"ALS {A6(6,8)} and {E1(3,6)} pivot {A1(3,8)}", map[string][]uint{"E6": {6}}},
+		// big5.txt
+		{"000000951000530000000002600300700016020080000040090300450600007080000009600028000", (*Board).SolveDeathBlossom,
+			"stem A1(2,7)", map[string][]uint{"J2": {1}}},
+	}
+
+	for _, test := range tests {
+		b := loadSimpleSolved(t, test.puzzle)
+		steps := testEliminations(t, b, func() error { return test.run(b) }, test.removed)
+		for _, step := range steps {
+			if !strings.HasPrefix(step.Description, test.prefix) {
+				t.Fatalf("%s: expected %q step, actual: %s", test.puzzle, test.prefix, step.Description)
+			}
+		}
+	}
+}
+
+func TestTurbotFish(t *testing.T) {
+	solvers := []func(*Board) error{(*Board).SolveSkyscraper, (*Board).SolveTwoStringKite, (*Board).SolveTurbotFish}
+
+	// big5.txt, each position only has the one kind
+	tests := []struct {
+		puzzle  string
+		kind    int
+		removed map[string][]uint
+	}{
+		{"000000320760015090001002000000000600000007000500090000056400009009050476010900080", 0, map[string][]uint{"D5": {3}}},
+		{"001090054300024000009100300070006000080200900045300010000970060000000000002000708", 1, map[string][]uint{"J8": {4}}},
+		{"000000560000578009000090000700000850900030700300120000070409028000000490010002000", 2, map[string][]uint{"C4": {3}}},
+	}
+
+	for _, test := range tests {
+		b := loadSimpleSolved(t, test.puzzle)
+		for kind, run := range solvers {
+			if kind != test.kind {
+				testEliminations(t, b, func() error { return run(b) }, nil)
+			}
+		}
+		testEliminations(t, b, func() error { return solvers[test.kind](b) }, test.removed)
+	}
+}
+
+func TestWWing(t *testing.T) {
+	// big5.txt, A7 and G9 are 4 or 5 and E7 or E9 is 4, so A7 or G9 is 5
+	b := loadSimpleSolved(t, "000000000040700089590000230070380090200070000306020008000000000650004000904003006")
+	testEliminations(t, b, b.SolveWWing, map[string][]uint{"A9": {5}, "J7": {5}})
+}
+
+func TestRemotePairs(t *testing.T) {
+	// big5.txt, J3 sees C3 and J8, the ends of a chain of four 7,8 pairs
+	b := loadSimpleSolved(t, "000007200000008004190000060000000007700020410029000008080735900001040000030000506")
+	testEliminations(t, b, b.SolveRemotePairs, map[string][]uint{"J3": {7}})
+}
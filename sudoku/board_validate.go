@@ -1,8 +1,8 @@
-package main
+package sudoku
 
 import "fmt"
 
-func (b *board) Validate() error {
+func (b *Board) Validate() error {
 	for pos := 0; pos < 81; pos++ {
 		var blit uint
 
@@ -62,7 +62,7 @@ func (b *board) Validate() error {
 	//	return b.ValidateKnownAnswer()
 }
 
-/*func (b *board) ValidateKnownAnswer() error {
+/*func (b *Board) ValidateKnownAnswer() error {
 	if b.knownAnswer == nil {
 		return nil
 	}
@@ -0,0 +1,206 @@
+package sudoku
+
+// The brute force solver works on a copy of the candidate bitmasks only. A
+// cell is placed when a single candidate is left, so there's no separate
+// solved array to keep in sync while backtracking.
+
+type bruteGrid [81]uint
+
+var (
+	bruteUnits [27][9]int
+	brutePeers [81][20]int
+)
+
+func init() {
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			bruteUnits[i][j] = i*9 + j                        // row
+			bruteUnits[9+i][j] = j*9 + i                      // column
+			bruteUnits[18+i][j] = (i/3*3+j/3)*9 + i%3*3 + j%3 // box
+		}
+	}
+
+	for pos := 0; pos < 81; pos++ {
+		n := 0
+		c1 := getCoords(pos)
+		for i := 0; i < 81; i++ {
+			c2 := getCoords(i)
+			if i != pos && (c1.row == c2.row || c1.col == c2.col || c1.box == c2.box) {
+				brutePeers[pos][n] = i
+				n++
+			}
+		}
+	}
+}
+
+// SolveBrute solves the board by backtracking over the candidate bitmasks,
+// propagating naked and hidden singles and branching on the cell with the
+// fewest candidates, or the digit with the fewest places left in a unit
+// (minimum remaining values). It records no steps and doesn't check the
+// solution is unique; it is meant for raw throughput.
+func (b *Board) SolveBrute() error {
+	var sln bruteGrid
+	if bruteSolve(bruteGrid(b.blits), 1, &sln) == 0 {
+		return NewErrUnsolvable("could not solve with brute force")
+	}
+
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] == 0 {
+			b.SolvePositionNoValidate(pos, GetSingleBitValue(sln[pos]))
+		}
+	}
+	return nil
+}
+
+// isUniqueBrute returns true if the board has exactly one solution, found
+// by brute force whatever the backend.
+func (b *Board) isUniqueBrute() bool {
+	var sln bruteGrid
+	return bruteSolve(bruteGrid(b.blits), 2, &sln) == 1
+}
+
+// bruteSolve returns the number of solutions of g up to max, 0 for no limit,
+// storing the first one in sln.
+func bruteSolve(g bruteGrid, max int, sln *bruteGrid) int {
+	var queue []int
+	for pos := 0; pos < 81; pos++ {
+		if HasSingleBit(g[pos]) {
+			queue = append(queue, pos)
+		}
+	}
+	if !g.propagate(queue) {
+		return 0
+	}
+	return g.search(max, 0, sln)
+}
+
+func (g *bruteGrid) search(max, found int, sln *bruteGrid) int {
+	// minimum remaining values, first the cell with the fewest candidates
+	best := -1
+	var bestCount uint = 10
+	for pos := 0; pos < 81; pos++ {
+		if HasSingleBit(g[pos]) {
+			continue
+		}
+		if n := GetNumberOfSetBits(g[pos]); n < bestCount {
+			best, bestCount = pos, n
+			if n == 2 {
+				break
+			}
+		}
+	}
+
+	if best == -1 {
+		if found == 0 {
+			*sln = *g
+		}
+		return found + 1
+	}
+
+	// a digit with fewer places left in a unit is a better branch
+	bestUnit := -1
+	var bestHint uint
+	if bestCount > 2 {
+		for u, unit := range bruteUnits {
+			for hint := uint(1); hint <= 0x100; hint <<= 1 {
+				var n uint
+				for _, pos := range unit {
+					if g[pos] == hint {
+						n = 0
+						break
+					}
+					if g[pos]&hint != 0 {
+						n++
+					}
+				}
+				if n >= 2 && n < bestCount {
+					bestUnit, bestHint, bestCount = u, hint, n
+				}
+			}
+		}
+	}
+
+	if bestUnit != -1 {
+		for _, pos := range bruteUnits[bestUnit] {
+			if g[pos]&bestHint == 0 {
+				continue
+			}
+			if found = g.branch(pos, bestHint, max, found, sln); max > 0 && found >= max {
+				break
+			}
+		}
+		return found
+	}
+
+	for blits := g[best]; blits != 0; blits &= blits - 1 {
+		if found = g.branch(best, blits&-blits, max, found, sln); max > 0 && found >= max {
+			break
+		}
+	}
+	return found
+}
+
+// branch searches a copy of g with hint placed at pos.
+func (g *bruteGrid) branch(pos int, hint uint, max, found int, sln *bruteGrid) int {
+	g2 := *g
+	g2[pos] = hint
+	if !g2.propagate([]int{pos}) {
+		return found
+	}
+	return g2.search(max, found, sln)
+}
+
+// propagate eliminates the value of each placed cell in queue from its
+// peers, placing naked and hidden singles as they appear. It returns false
+// on a contradiction.
+func (g *bruteGrid) propagate(queue []int) bool {
+	for {
+		for len(queue) != 0 {
+			pos := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+
+			hint := g[pos]
+			for _, peer := range brutePeers[pos] {
+				if g[peer]&hint == 0 {
+					continue
+				}
+				g[peer] &^= hint
+				if g[peer] == 0 {
+					return false
+				}
+				if HasSingleBit(g[peer]) {
+					queue = append(queue, peer)
+				}
+			}
+		}
+
+		// hidden singles: a digit left in a single cell of a unit
+		for _, unit := range bruteUnits {
+			var once, twice uint
+			for _, pos := range unit {
+				twice |= once & g[pos]
+				once |= g[pos]
+			}
+			if once != 0x1FF {
+				return false
+			}
+
+			for hidden := once &^ twice; hidden != 0; hidden &= hidden - 1 {
+				hint := hidden & -hidden
+				for _, pos := range unit {
+					if g[pos]&hint != 0 {
+						if g[pos] != hint {
+							g[pos] = hint
+							queue = append(queue, pos)
+						}
+						break
+					}
+				}
+			}
+		}
+
+		if len(queue) == 0 {
+			return true
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package sudoku
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+)
+
+func readPuzzles(tb testing.TB, fileName string) []string {
+	f, err := os.Open(fileName)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer f.Close()
+
+	var list []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.Replace(strings.TrimSpace(scanner.Text()), " ", "", -1)
+		if line != "" {
+			list = append(list, line)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		tb.Fatal(err)
+	}
+	return list
+}
+
+func TestSolveBrute(t *testing.T) {
+	for _, puzzle := range readPuzzles(t, "../test_files/top95.txt") {
+		b, err := LoadBoard([]byte(puzzle))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = b.SolveBrute(); err != nil {
+			t.Fatalf("%s: %s", puzzle, err)
+		}
+		if !b.IsSolved() {
+			t.Fatalf("%s: could not solve", puzzle)
+		}
+		if err = b.Validate(); err != nil {
+			t.Fatalf("%s: %s", puzzle, err)
+		}
+
+		solution := b.GetCompact()
+		for pos := 0; pos < 81; pos++ {
+			if puzzle[pos] != '.' && puzzle[pos] != '0' && puzzle[pos] != '_' && puzzle[pos] != solution[pos] {
+				t.Fatalf("%s: solution %s doesn't keep the clues", puzzle, solution)
+			}
+		}
+	}
+
+	// 21_ywing.txt with A1 and A2 removed has 13 solutions
+	b, err := LoadBoard([]byte("007300090000600271126090384705000162000200800000000009001076923300100450000053018"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sln bruteGrid
+	if n := bruteSolve(bruteGrid(b.blits), 0, &sln); n != 13 {
+		t.Fatalf("expected 13 solutions, actual: %d", n)
+	}
+
+	b, err = LoadBoardFile("../test_files/input_no_solution3.txt")
+	if err == nil {
+		if err = b.SolveBrute(); err == nil {
+			t.Fatal("expected unsolvable")
+		}
+	}
+}
+
+// benchmarkBoards caches the loaded boards, benchmarks run several times
+var benchmarkBoards = make(map[string][]*Board)
+
+func benchmarkSolveBrute(b *testing.B, fileName string, maxPuzzles int) {
+	boards, ok := benchmarkBoards[fileName]
+	if !ok {
+		for _, puzzle := range readPuzzles(b, fileName) {
+			if len(boards) == maxPuzzles {
+				break
+			}
+			board, err := LoadBoard([]byte(puzzle))
+			if err != nil {
+				b.Fatal(err)
+			}
+			boards = append(boards, board)
+		}
+		benchmarkBoards[fileName] = boards
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board := *boards[i%len(boards)]
+		if err := board.SolveBrute(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSolveBruteTop95(b *testing.B) {
+	benchmarkSolveBrute(b, "../test_files/top95.txt", -1)
+}
+
+func BenchmarkSolveBruteSudoku17(b *testing.B) {
+	// the first 5000 of the 49151 puzzles, loading them all takes too long
+	benchmarkSolveBrute(b, "../test_files/sudoku17.txt", 5000)
+}
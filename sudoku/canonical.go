@@ -0,0 +1,131 @@
+package sudoku
+
+import "bytes"
+
+var permutations3 = [][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+
+// Canonical returns a compact form, with '0' for empty cells, which is the
+// same for all puzzles equivalent under the Sudoku symmetries: relabeling
+// the digits, transposing, and permuting the bands, the stacks, the rows
+// within a band and the columns within a stack. It is the smallest compact
+// form of all the equivalent puzzles, so two puzzles are equivalent if and
+// only if their canonical forms are equal.
+func (b *Board) Canonical() string {
+	var grid [81]byte
+	for i := 0; i < 81; i++ {
+		grid[i] = byte(b.solved[i])
+	}
+
+	c := &canonicalizer{}
+	for t := 0; t < 2; t++ {
+		g := grid
+		if t == 1 {
+			for i := 0; i < 81; i++ {
+				g[i] = grid[(i%9)*9+i/9]
+			}
+		}
+
+		// try every column order, the row order is searched for
+		var cols [9]int
+		for _, stacks := range permutations3 {
+			for _, c0 := range permutations3 {
+				for _, c1 := range permutations3 {
+					for _, c2 := range permutations3 {
+						within := [3][3]int{c0, c1, c2}
+						for s := 0; s < 3; s++ {
+							for i := 0; i < 3; i++ {
+								cols[s*3+i] = stacks[s]*3 + within[s][i]
+							}
+						}
+						for r := 0; r < 9; r++ {
+							for col := 0; col < 9; col++ {
+								c.rows[r][col] = g[r*9+cols[col]]
+							}
+						}
+						c.search(0, 0, 0, [10]byte{}, 1)
+					}
+				}
+			}
+		}
+	}
+
+	for i := 0; i < 81; i++ {
+		c.best[i] += '0'
+	}
+	return string(c.best[:])
+}
+
+// canonicalizer finds the smallest relabeled grid over the row orders of
+// rows, the column order being fixed by the caller.
+type canonicalizer struct {
+	rows    [9][9]byte
+	cur     [81]byte
+	best    [81]byte
+	hasBest bool
+}
+
+type canonicalRow struct {
+	row     int
+	out     [9]byte
+	mapping [10]byte
+	next    byte
+}
+
+// search picks the row placed at level. Digits are relabeled in order of
+// first appearance, so the row giving the smallest output only depends on
+// the rows placed before it; every row tying for smallest is tried.
+func (c *canonicalizer) search(level int, used uint, band int, mapping [10]byte, next byte) {
+	if level == 9 {
+		if !c.hasBest || bytes.Compare(c.cur[:], c.best[:]) < 0 {
+			c.best = c.cur
+			c.hasBest = true
+		}
+		return
+	}
+
+	var candidates []canonicalRow
+	for r := 0; r < 9; r++ {
+		if used&(1<<uint(r)) != 0 {
+			continue
+		}
+		// a band's rows are placed together
+		if level%3 == 0 {
+			if used&(7<<uint(r/3*3)) != 0 {
+				continue
+			}
+		} else if r/3 != band {
+			continue
+		}
+
+		cand := canonicalRow{row: r, mapping: mapping, next: next}
+		for col, v := range c.rows[r] {
+			if v != 0 && cand.mapping[v] == 0 {
+				cand.mapping[v] = cand.next
+				cand.next++
+			}
+			cand.out[col] = cand.mapping[v]
+		}
+		candidates = append(candidates, cand)
+	}
+
+	min := candidates[0].out
+	for _, cand := range candidates[1:] {
+		if bytes.Compare(cand.out[:], min[:]) < 0 {
+			min = cand.out
+		}
+	}
+
+	start, end := level*9, level*9+9
+	copy(c.cur[start:end], min[:])
+	if c.hasBest && bytes.Compare(c.cur[:end], c.best[:end]) > 0 {
+		return
+	}
+
+	for _, cand := range candidates {
+		if cand.out != min {
+			continue
+		}
+		copy(c.cur[start:end], min[:])
+		c.search(level+1, used|1<<uint(cand.row), cand.row/3, cand.mapping, cand.next)
+	}
+}
@@ -0,0 +1,63 @@
+package sudoku
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	const puzzle = "487300090000600271126090384705000162000200800000000009001076923300100450000053018"
+	b, err := LoadBoard([]byte(puzzle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := b.Canonical()
+
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 20; n++ {
+		// relabel, transpose, and shuffle bands, stacks, rows and columns
+		digits := r.Perm(9)
+		var rowOrder, colOrder [9]int
+		for i, band := range r.Perm(3) {
+			for j, row := range r.Perm(3) {
+				rowOrder[i*3+j] = band*3 + row
+			}
+		}
+		for i, stack := range r.Perm(3) {
+			for j, col := range r.Perm(3) {
+				colOrder[i*3+j] = stack*3 + col
+			}
+		}
+		transpose := r.Intn(2) == 1
+
+		input := make([]byte, 81)
+		for i := 0; i < 81; i++ {
+			row, col := rowOrder[i/9], colOrder[i%9]
+			if transpose {
+				row, col = col, row
+			}
+			v := puzzle[row*9+col]
+			if v != '0' {
+				v = byte('1' + digits[v-'1'])
+			}
+			input[i] = v
+		}
+
+		b2, err := LoadBoard(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := b2.Canonical(); actual != expected {
+			t.Fatalf("%s: expected canonical %s actual %s", input, expected, actual)
+		}
+	}
+
+	// A1 removed is a different puzzle
+	b2, err := LoadBoard([]byte("0" + puzzle[1:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b2.Canonical() == expected {
+		t.Fatal("expected different canonical forms")
+	}
+}
@@ -0,0 +1,133 @@
+package sudoku
+
+// dlx is an exact cover problem solved with Knuth's Algorithm X using
+// dancing links: https://arxiv.org/abs/cs/0011047
+//
+// Node 0 is the root, nodes 1 to columns are the column headers and the
+// rest are row nodes. The links are kept in slices rather than pointers.
+type dlx struct {
+	left, right, up, down []int
+	column                []int
+	rowID                 []int
+	size                  []int
+
+	partial   []int
+	solutions [][]int
+	max       int
+}
+
+func newDLX(columns int) *dlx {
+	d := &dlx{}
+	for i := 0; i <= columns; i++ {
+		d.left = append(d.left, i-1)
+		d.right = append(d.right, i+1)
+		d.up = append(d.up, i)
+		d.down = append(d.down, i)
+		d.column = append(d.column, i)
+		d.rowID = append(d.rowID, -1)
+		d.size = append(d.size, 0)
+	}
+	d.left[0] = columns
+	d.right[columns] = 0
+	return d
+}
+
+// addRow adds a row covering the 0-based columns. id is returned in the
+// solutions when the row is picked.
+func (d *dlx) addRow(id int, columns []int) {
+	first := len(d.left)
+	for i, c := range columns {
+		c++ // skip the root
+		node := len(d.left)
+
+		// insert at the bottom of the column
+		d.up = append(d.up, d.up[c])
+		d.down = append(d.down, c)
+		d.down[d.up[c]] = node
+		d.up[c] = node
+		d.size[c]++
+
+		// insert at the end of the row
+		if i == 0 {
+			d.left = append(d.left, node)
+			d.right = append(d.right, node)
+		} else {
+			d.left = append(d.left, node-1)
+			d.right = append(d.right, first)
+			d.right[node-1] = node
+			d.left[first] = node
+		}
+		d.column = append(d.column, c)
+		d.rowID = append(d.rowID, id)
+	}
+}
+
+// solve returns the row ids of up to max solutions, 0 for no limit.
+func (d *dlx) solve(max int) [][]int {
+	d.max = max
+	d.solutions = nil
+	d.search()
+	return d.solutions
+}
+
+// search returns true once max solutions are found.
+func (d *dlx) search() bool {
+	if d.right[0] == 0 {
+		d.solutions = append(d.solutions, append([]int(nil), d.partial...))
+		return d.max > 0 && len(d.solutions) >= d.max
+	}
+
+	// choose the column with the fewest rows
+	c := d.right[0]
+	for j := d.right[c]; j != 0; j = d.right[j] {
+		if d.size[j] < d.size[c] {
+			c = j
+		}
+	}
+	if d.size[c] == 0 {
+		return false
+	}
+
+	d.cover(c)
+	done := false
+	for r := d.down[c]; r != c && !done; r = d.down[r] {
+		d.partial = append(d.partial, d.rowID[r])
+		for j := d.right[r]; j != r; j = d.right[j] {
+			d.cover(d.column[j])
+		}
+
+		done = d.search()
+
+		for j := d.left[r]; j != r; j = d.left[j] {
+			d.uncover(d.column[j])
+		}
+		d.partial = d.partial[:len(d.partial)-1]
+	}
+	d.uncover(c)
+
+	return done
+}
+
+func (d *dlx) cover(c int) {
+	d.right[d.left[c]] = d.right[c]
+	d.left[d.right[c]] = d.left[c]
+	for i := d.down[c]; i != c; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.size[d.column[j]]--
+		}
+	}
+}
+
+func (d *dlx) uncover(c int) {
+	for i := d.up[c]; i != c; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.column[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[c]] = c
+	d.left[d.right[c]] = c
+}
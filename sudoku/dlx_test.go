@@ -0,0 +1,102 @@
+package sudoku
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDLX(t *testing.T) {
+	// Knuth's example, the only cover is rows 0, 3 and 4
+	rows := [][]int{
+		{2, 4, 5},
+		{0, 3, 6},
+		{1, 2, 5},
+		{0, 3},
+		{1, 6},
+		{3, 4, 6},
+	}
+	d := newDLX(7)
+	for i, row := range rows {
+		d.addRow(i, row)
+	}
+
+	slns := d.solve(0)
+	if len(slns) != 1 {
+		t.Fatalf("expected 1 solution, actual: %v", slns)
+	}
+	sort.Ints(slns[0])
+	if expected := []int{0, 3, 4}; !reflect.DeepEqual(slns[0], expected) {
+		t.Fatalf("expected %v actual %v", expected, slns[0])
+	}
+}
+
+func TestSolveDLX(t *testing.T) {
+	for _, file := range testBoardFiles {
+		b, err := LoadBoardFile(file)
+		if err != nil {
+			t.Fatalf("%s: %s", file, err)
+		}
+		puzzle := b.GetCompact()
+
+		if err = b.SolveDLX(); err != nil {
+			t.Fatalf("%s: %s", file, err)
+		}
+		if !b.IsSolved() {
+			t.Fatalf("%s: could not solve", file)
+		}
+		solution := b.GetCompact()
+		for pos := 0; pos < 81; pos++ {
+			if puzzle[pos] != '0' && puzzle[pos] != solution[pos] {
+				t.Fatalf("%s: solution %s doesn't keep the clues", file, solution)
+			}
+		}
+	}
+}
+
+func TestSolutionsDLX(t *testing.T) {
+	inputs := []string{
+		"487300090000600271126090384705000162000200800000000009001076923300100450000053018",
+		// 21_ywing.txt with A1 and A2 removed has 13 solutions
+		"007300090000600271126090384705000162000200800000000009001076923300100450000053018",
+		"000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	}
+	maxes := []int{2, 20, 100}
+	expecteds := []int{1, 13, 100}
+
+	for i, input := range inputs {
+		b, err := LoadBoard([]byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.SetBackend(BackendDLX)
+
+		list, err := b.Solutions(maxes[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != expecteds[i] {
+			t.Fatalf("%s: expected %d solutions actual %d", input, expecteds[i], len(list))
+		}
+	}
+}
+
+func TestSolveBackendDLX(t *testing.T) {
+	// 29_ben.txt needs the backend
+	b, err := LoadBoardFile("../test_files/29_ben.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetBackend(BackendDLX)
+	if err = b.Solve(); err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsSolved() {
+		t.Fatal("could not solve")
+	}
+
+	used := b.TechniquesUsed()
+	if len(used) == 0 || used[len(used)-1] != "DLX" {
+		t.Fatalf("expected DLX to be used last, actual: %v", used)
+	}
+}
@@ -0,0 +1,27 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrGenerateBudget indicates puzzle generation ran out of attempts or time
+// before finding a puzzle matching the requested options.
+var ErrGenerateBudget = errors.New("generate: no matching puzzle found within the attempt/time budget")
+
+// ErrUnsolvable indicates a Sudoku board is unsolvable
+type ErrUnsolvable struct {
+	msg string
+}
+
+// NewErrUnsolvable returns a new ErrUnsolvable error
+func NewErrUnsolvable(format string, a ...interface{}) ErrUnsolvable {
+	return ErrUnsolvable{msg: fmt.Sprintf(format, a...)}
+}
+
+func (e ErrUnsolvable) Error() string {
+	return e.msg
+}
+
+// ErrNotUnique indicates a puzzle has no solution or more than one solution.
+var ErrNotUnique = errors.New("puzzle does not have a unique solution")
@@ -0,0 +1,405 @@
+package sudoku
+
+// The generate/grading function is a work in progress.
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenerateOptions control puzzle generation. The difficulty of a puzzle is
+// the sum of the technique difficulties applied while solving it (see
+// Difficulty). A zero MaxDifficulty, MaxAttempts or Timeout means no limit.
+//
+// Minimal keeps removing clues until none can be removed without losing
+// the unique solution; such puzzles may need SAT unless MaxTechnique is set,
+// in which case puzzles needing harder techniques are retried. Minimal can't
+// be combined with a fixed Symmetry.
+//
+// Rand is the source of randomness; the same Rand seed and options always
+// produce the same puzzle, or ErrGenerateBudget if MaxAttempts ran out
+// first. Timeout depends on the machine and load, so leave it zero to
+// reproduce a puzzle from its seed. A nil Rand uses a source seeded from
+// the clock.
+//
+// RequiredTechniques and MaxTechnique use the names returned by Techniques.
+// Each required technique must make progress at least once while solving
+// the puzzle, and no technique rated harder than MaxTechnique may be needed. Generated puzzles have a unique solution,
+// so they are graded with SetAssumeUnique.
+type GenerateOptions struct {
+	MinDifficulty      int
+	MaxDifficulty      int
+	RequiredTechniques []string
+	MaxTechnique       string
+	Symmetry           Symmetry
+	Minimal            bool
+	MaxAttempts        int
+	Timeout            time.Duration
+	Rand               *rand.Rand
+}
+
+var difficultyLevels = []struct {
+	name     string
+	min, max int
+}{
+	{name: "easy", min: 0, max: 10},
+	{name: "medium", min: 11, max: 25},
+	{name: "hard", min: 26, max: 50},
+	{name: "expert", min: 51, max: 0},
+}
+
+// ParseDifficulty parses a named difficulty level (easy, medium, hard,
+// expert), a numeric range such as "20-40" or a single number into
+// a minimum and maximum difficulty. A maximum of 0 means no upper limit.
+func ParseDifficulty(s string) (int, int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, level := range difficultyLevels {
+		if s == level.name {
+			return level.min, level.max, nil
+		}
+	}
+
+	parts := strings.SplitN(s, "-", 2)
+	min, err := strconv.Atoi(parts[0])
+	if err != nil || min < 0 {
+		return 0, 0, fmt.Errorf("invalid difficulty %q, expected easy, medium, hard, expert or a range such as 20-40", s)
+	}
+	if len(parts) == 1 {
+		return min, min, nil
+	}
+	max, err := strconv.Atoi(parts[1])
+	if err != nil || max < min {
+		return 0, 0, fmt.Errorf("invalid difficulty %q, expected easy, medium, hard, expert or a range such as 20-40", s)
+	}
+	return min, max, nil
+}
+
+func getValidBoard(r *rand.Rand) (*Board, error) {
+	b, err := LoadBoard([]byte("000000000000000000000000000000000000000000000000000000000000000000000000000000000"))
+	if err != nil {
+		return nil, err
+	}
+
+	for !b.IsSolved() {
+		n := r.Intn(81)
+		if b.solved[n] != 0 {
+			continue
+		}
+		bitList := GetBitList(b.blits[n])
+		bn := r.Intn(len(bitList))
+		val := GetSingleBitValue(bitList[bn])
+
+		err = b.SolvePosition(n, val)
+		if err != nil {
+			return nil, err
+		}
+
+		err = b.SolveWithSolversList(b.getFillSolvers())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// GeneratePuzzle generates a random puzzle with a single solution and
+// a difficulty between minDifficulty and maxDifficulty (0 for no limit).
+func GeneratePuzzle(minDifficulty, maxDifficulty int) (*Board, error) {
+	return Generate(GenerateOptions{MinDifficulty: minDifficulty, MaxDifficulty: maxDifficulty})
+}
+
+// Generate generates a random puzzle with a single solution, retrying until
+// its difficulty falls within the requested range or the attempt or time
+// budget is used up, in which case ErrGenerateBudget is returned.
+func Generate(opts GenerateOptions) (*Board, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+
+	r := opts.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	for attempt := 0; opts.MaxAttempts <= 0 || attempt < opts.MaxAttempts; attempt++ {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+
+		var err error
+		var b *Board
+		for b == nil || err != nil {
+			b, err = getValidBoard(r)
+		}
+		b2, err := digHoles(r, b, opts, deadline)
+		if err != nil {
+			return nil, err
+		}
+
+		solvers := opts.getSolvers
+		if opts.Minimal {
+			if err = b2.minimize(r.Perm(81)); err != nil {
+				return nil, err
+			}
+			if opts.MaxTechnique == "" {
+				solvers = func(b *Board) []solver { return b.getSolvers() }
+			}
+		}
+
+		b3, err := LoadBoard([]byte(b2.GetCompact()))
+		if err != nil {
+			return nil, err
+		}
+		b3.SetAssumeUnique(true)
+		if err = b3.SolveWithSolversList(solvers(b3)); err != nil {
+			return nil, err
+		}
+		if !b3.IsSolved() {
+			continue
+		}
+
+		b2.difficulty = b3.difficulty
+		b2.solversUsed = b3.solversUsed
+		if opts.isTargetReached(b3) && opts.inRange(b2.difficulty) {
+			return b2, nil
+		}
+	}
+
+	return nil, ErrGenerateBudget
+}
+
+func (opts GenerateOptions) validate() error {
+	if opts.Symmetry < SymmetryAuto || opts.Symmetry > SymmetryDihedral {
+		return fmt.Errorf("unknown symmetry %d", opts.Symmetry)
+	}
+	if opts.Minimal && opts.Symmetry != SymmetryAuto && opts.Symmetry != SymmetryNone {
+		return fmt.Errorf("minimal puzzles can't keep %s symmetry", opts.Symmetry)
+	}
+
+	names := Techniques()
+	isKnown := func(name string) bool {
+		for _, known := range names {
+			if name == known && !isBackendSolver(name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if opts.MaxTechnique != "" && !isKnown(opts.MaxTechnique) {
+		return fmt.Errorf("unknown max technique %q", opts.MaxTechnique)
+	}
+
+	allowed := opts.getSolvers(&Board{})
+	for _, required := range opts.RequiredTechniques {
+		if !isKnown(required) {
+			return fmt.Errorf("unknown required technique %q", required)
+		}
+		found := false
+		for _, solver := range allowed {
+			if solver.name == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("required technique %q is harder than max technique %q", required, opts.MaxTechnique)
+		}
+	}
+	return nil
+}
+
+func (opts GenerateOptions) inRange(difficulty int) bool {
+	return difficulty >= opts.MinDifficulty && (opts.MaxDifficulty <= 0 || difficulty <= opts.MaxDifficulty)
+}
+
+// isTargetReached returns true if the solved board b met the minimum
+// difficulty and used all required techniques.
+func (opts GenerateOptions) isTargetReached(b *Board) bool {
+	if b.difficulty < opts.MinDifficulty {
+		return false
+	}
+	for _, required := range opts.RequiredTechniques {
+		found := false
+		for _, used := range b.solversUsed {
+			if used == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// getSolvers returns the generator solvers for b rated no harder than
+// MaxTechnique, in solve order.
+func (opts GenerateOptions) getSolvers(b *Board) []solver {
+	all := b.getGeneratorSolvers()
+	if opts.MaxTechnique == "" {
+		return all
+	}
+
+	max := -1
+	for _, solver := range all {
+		if solver.name == opts.MaxTechnique {
+			max = solver.difficulty
+			break
+		}
+	}
+
+	var solvers []solver
+	for _, solver := range all {
+		if solver.difficulty <= max {
+			solvers = append(solvers, solver)
+		}
+	}
+	return solvers
+}
+
+// digHoles removes clues from the solved board b while the puzzle stays
+// within opts. ErrGenerateBudget is returned if the deadline passes, rather
+// than a partly dug puzzle which would depend on timing.
+func digHoles(r *rand.Rand, b *Board, opts GenerateOptions, deadline time.Time) (*Board, error) {
+	b2 := &Board{solved: b.solved, blits: b.blits}
+
+	// the 27 clue floor only applies once the minimum difficulty is reached
+	// and the required techniques are used, and never to minimal puzzles
+	targetReached := opts.isTargetReached(&Board{})
+
+	step := 4
+	failures := 0
+	check := make(map[int]interface{})
+	for len(check) != 81 && (opts.Minimal || b2.numSolved() >= 27 || !targetReached) {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, ErrGenerateBudget
+		}
+
+		goodSolved := b2.solved
+		goodBlits := b2.blits
+
+		pos1 := r.Intn(81)
+		cells := opts.Symmetry.digOrbit(pos1, step)
+		if step == 1 || opts.Symmetry != SymmetryAuto {
+			if _, ok := check[pos1]; ok {
+				continue
+			}
+			// the set of cells dug together is only tried once
+			for _, pos := range cells {
+				check[pos] = struct{}{}
+			}
+		}
+
+		allSolved := true
+		for _, pos := range cells {
+			if b2.solved[pos] == 0 {
+				allSolved = false
+			}
+		}
+		if !allSolved {
+			continue
+		}
+
+		for _, pos := range cells {
+			b2.solved[pos] = 0
+		}
+
+		// attempt to solve using selected difficulty
+		b3, err := LoadBoard([]byte(b2.GetCompact()))
+		if err != nil {
+			return nil, err
+		}
+
+		// a dig with more than one solution is rejected by brute force
+		// first, the human techniques would take much longer to give up,
+		// and a unique dig can be graded with the uniqueness techniques
+		unique := b3.isUniqueBrute()
+		if unique {
+			b3.SetAssumeUnique(true)
+			err = b3.SolveWithSolversList(opts.getSolvers(b3))
+		}
+
+		if !unique || err != nil || !b3.IsSolved() ||
+			(opts.MaxDifficulty > 0 && b3.difficulty > opts.MaxDifficulty) ||
+			(targetReached && !opts.isTargetReached(b3)) {
+			// bad dig, doesn't fit difficulty or techniques, or more than one solution
+			b2.solved = goodSolved
+			b2.blits = goodBlits
+			failures++
+			if opts.Symmetry == SymmetryAuto && step > 1 && failures == 2 {
+				failures = 0
+				step /= 2
+			}
+		} else {
+			targetReached = opts.isTargetReached(b3)
+		}
+	}
+
+	return b2, nil
+}
+
+func (b *Board) getHints(pos int) (uint, error) {
+	check := make(map[uint]interface{})
+	for i := uint(1); i <= 9; i++ {
+		check[i] = struct{}{}
+	}
+
+	removeHints := func(target int, source int) error {
+		if target == source {
+			return nil
+		}
+		val := b.solved[target]
+		if val == 0 {
+			return nil
+		}
+
+		if _, ok := check[val]; ok {
+			delete(check, val)
+		}
+
+		return nil
+	}
+
+	if err := b.operateOnRCB(pos, removeHints); err != nil {
+		return 0, err
+	}
+
+	blits := uint(0)
+	for k := range check {
+		blits |= 1 << (k - 1)
+	}
+	return blits, nil
+}
+
+func (b *Board) getGeneratorSolvers() []solver {
+	var solvers []solver
+	for _, solver := range b.getSolvers() {
+		if !isBackendSolver(solver.name) {
+			solvers = append(solvers, solver)
+		}
+	}
+
+	return solvers
+}
+
+// getFillSolvers returns the solvers propagating the random placements of
+// getValidBoard. Contradictions they miss surface on later placements and
+// the board is retried, so the harder techniques would only slow it down.
+func (b *Board) getFillSolvers() []solver {
+	return []solver{
+		{name: "NAKED SINGLE", run: b.SolveNakedSingle},
+		{name: "HIDDEN SINGLE", run: b.SolveHiddenSingle},
+		{name: "POINTING PAIR AND TRIPLE REDUCTION", run: b.SolvePointingPairAndTripleReduction},
+		{name: "BOX LINE", run: b.SolveBoxLine},
+	}
+}
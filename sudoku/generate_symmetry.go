@@ -0,0 +1,119 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Symmetry is the clue symmetry kept while digging holes in a generated
+// puzzle. Every dig removes a cell together with all of its images under
+// the symmetry, so the final clue pattern is symmetric.
+type Symmetry int
+
+const (
+	// SymmetryAuto digs 4 cells mirrored on both axes, then 2 cells rotated
+	// 180 degrees, then single cells as digging gets harder. The result is
+	// not guaranteed to be symmetric.
+	SymmetryAuto Symmetry = iota
+	// SymmetryNone digs single cells.
+	SymmetryNone
+	// SymmetryRotational keeps 180 degree rotational symmetry.
+	SymmetryRotational
+	// SymmetryVertical mirrors across the vertical axis (left/right).
+	SymmetryVertical
+	// SymmetryHorizontal mirrors across the horizontal axis (top/bottom).
+	SymmetryHorizontal
+	// SymmetryDiagonal mirrors across the main diagonal.
+	SymmetryDiagonal
+	// SymmetryRotational90 keeps 90 degree rotational symmetry.
+	SymmetryRotational90
+	// SymmetryDihedral keeps all rotations and mirrors of the square.
+	SymmetryDihedral
+)
+
+var symmetryNames = []string{"auto", "none", "rotational", "vertical", "horizontal", "diagonal", "rotational90", "dihedral"}
+
+func (s Symmetry) String() string {
+	if s < 0 || int(s) >= len(symmetryNames) {
+		return fmt.Sprintf("Symmetry(%d)", int(s))
+	}
+	return symmetryNames[s]
+}
+
+// ParseSymmetry parses a symmetry name as returned by Symmetry.String.
+func ParseSymmetry(s string) (Symmetry, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, name := range symmetryNames {
+		if s == name {
+			return Symmetry(i), nil
+		}
+	}
+	return SymmetryAuto, fmt.Errorf("unknown symmetry %q, expected one of %s", s, strings.Join(symmetryNames, ", "))
+}
+
+// transform maps a row and column to their image.
+type transform func(row, col int) (int, int)
+
+var (
+	identity     transform = func(r, c int) (int, int) { return r, c }
+	mirrorV      transform = func(r, c int) (int, int) { return r, 8 - c }
+	mirrorH      transform = func(r, c int) (int, int) { return 8 - r, c }
+	mirrorD      transform = func(r, c int) (int, int) { return c, r }
+	mirrorAD     transform = func(r, c int) (int, int) { return 8 - c, 8 - r }
+	rotate90     transform = func(r, c int) (int, int) { return c, 8 - r }
+	rotate180    transform = func(r, c int) (int, int) { return 8 - r, 8 - c }
+	rotate270    transform = func(r, c int) (int, int) { return 8 - c, r }
+	mirrorHV               = []transform{identity, mirrorV, mirrorH, rotate180}
+	symmetryMaps           = [][]transform{
+		SymmetryNone:         {identity},
+		SymmetryRotational:   {identity, rotate180},
+		SymmetryVertical:     {identity, mirrorV},
+		SymmetryHorizontal:   {identity, mirrorH},
+		SymmetryDiagonal:     {identity, mirrorD},
+		SymmetryRotational90: {identity, rotate90, rotate180, rotate270},
+		SymmetryDihedral:     {identity, rotate90, rotate180, rotate270, mirrorV, mirrorH, mirrorD, mirrorAD},
+	}
+)
+
+// orbit returns pos and its distinct images under the transforms.
+func orbit(pos int, transforms []transform) []int {
+	var list []int
+	coords := getCoords(pos)
+	for _, t := range transforms {
+		r, c := t(coords.row, coords.col)
+		list = appendUnique(list, r*9+c)
+	}
+	return list
+}
+
+// digOrbit returns the cells to dig together with pos. For SymmetryAuto
+// step is the current 4, 2 or 1 cell digging schedule.
+func (s Symmetry) digOrbit(pos int, step int) []int {
+	if s == SymmetryAuto {
+		switch step {
+		case 4:
+			return orbit(pos, mirrorHV)
+		case 2:
+			return orbit(pos, symmetryMaps[SymmetryRotational])
+		default:
+			return []int{pos}
+		}
+	}
+	return orbit(pos, symmetryMaps[s])
+}
+
+// IsSymmetric returns true if the clue pattern of b has the symmetry s.
+// SymmetryAuto and SymmetryNone are always satisfied.
+func (b *Board) IsSymmetric(s Symmetry) bool {
+	if s == SymmetryAuto || s == SymmetryNone {
+		return true
+	}
+	for pos := 0; pos < 81; pos++ {
+		for _, image := range s.digOrbit(pos, 0) {
+			if (b.solved[pos] == 0) != (b.solved[image] == 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
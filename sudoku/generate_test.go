@@ -0,0 +1,204 @@
+package sudoku
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParseDifficulty(t *testing.T) {
+	inputs := []string{"easy", "Expert", "20-40", "30"}
+	expecteds := [][2]int{{0, 10}, {51, 0}, {20, 40}, {30, 30}}
+
+	for i, input := range inputs {
+		min, max, err := ParseDifficulty(input)
+		if err != nil {
+			t.Fatalf("%q: %s", input, err)
+		}
+		if min != expecteds[i][0] || max != expecteds[i][1] {
+			t.Fatalf("%q: expected %v actual [%d %d]", input, expecteds[i], min, max)
+		}
+	}
+
+	for _, input := range []string{"", "impossible", "40-20", "-5"} {
+		if _, _, err := ParseDifficulty(input); err == nil {
+			t.Fatalf("%q: expected error", input)
+		}
+	}
+}
+
+func TestGenerateDifficulty(t *testing.T) {
+	// arrange
+	opts := GenerateOptions{MinDifficulty: 11, MaxDifficulty: 15, MaxAttempts: 50, Rand: rand.New(rand.NewSource(1))}
+
+	// act
+	b, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+	b2, err := LoadBoard([]byte(b.GetCompact()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = b2.SolveWithSolversList(b2.getGeneratorSolvers()); err != nil {
+		t.Fatal(err)
+	}
+	if !b2.IsSolved() {
+		t.Fatal("generated puzzle not solvable without SAT")
+	}
+	if b2.Difficulty() != b.Difficulty() || b.Difficulty() < opts.MinDifficulty || b.Difficulty() > opts.MaxDifficulty {
+		t.Fatalf("expected difficulty in [%d,%d], actual generated=%d solved=%d",
+			opts.MinDifficulty, opts.MaxDifficulty, b.Difficulty(), b2.Difficulty())
+	}
+}
+
+func TestGenerateBudget(t *testing.T) {
+	_, err := Generate(GenerateOptions{MinDifficulty: 100000, MaxAttempts: 1, Rand: rand.New(rand.NewSource(1))})
+	if err != ErrGenerateBudget {
+		t.Fatalf("expected ErrGenerateBudget, actual: %v", err)
+	}
+}
+
+func TestGenerateTechniques(t *testing.T) {
+	// arrange
+	opts := GenerateOptions{
+		RequiredTechniques: []string{"POINTING PAIR AND TRIPLE REDUCTION"},
+		MaxTechnique:       "BOX LINE",
+		MaxAttempts:        50,
+		Rand:               rand.New(rand.NewSource(12)),
+	}
+
+	// act
+	b, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// assert
+	b2, err := LoadBoard([]byte(b.GetCompact()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = b2.SolveWithSolversList(opts.getSolvers(b2)); err != nil {
+		t.Fatal(err)
+	}
+	if !b2.IsSolved() {
+		t.Fatal("generated puzzle needs techniques harder than BOX LINE")
+	}
+	if !opts.isTargetReached(b2) {
+		t.Fatalf("expected required techniques %v, actual %v", opts.RequiredTechniques, b2.TechniquesUsed())
+	}
+}
+
+func TestGenerateTechniquesValidate(t *testing.T) {
+	inputs := []GenerateOptions{
+		{MaxTechnique: "FOO"},
+		{RequiredTechniques: []string{"FOO"}},
+		{RequiredTechniques: []string{"SAT"}},
+		{RequiredTechniques: []string{"JELLYFISH"}, MaxTechnique: "X-WING"},
+	}
+
+	for _, input := range inputs {
+		if _, err := Generate(input); err == nil {
+			t.Fatalf("%#v: expected error", input)
+		}
+	}
+}
+
+func TestGenerateMaxTechnique(t *testing.T) {
+	// techniques rated as hard as X-WING are allowed wherever they are listed
+	opts := GenerateOptions{MaxTechnique: "X-WING"}
+	expecteds := map[string]bool{
+		"HIDDEN QUINT":        true,
+		"Y-WING":              true,
+		"SWORDFISH":           true,
+		"XY-CHAIN":            true,
+		"JELLYFISH":           false,
+		"FINNED X-WING":       false,
+		"WXYZ-WING":           false,
+		"CELL FORCING CHAINS": false,
+	}
+
+	allowed := make(map[string]bool)
+	for _, solver := range opts.getSolvers(&Board{}) {
+		allowed[solver.name] = true
+	}
+	for name, expected := range expecteds {
+		if allowed[name] != expected {
+			t.Fatalf("%s: expected allowed=%t", name, expected)
+		}
+	}
+}
+
+func TestGenerateSymmetry(t *testing.T) {
+	for s := SymmetryNone; s <= SymmetryDihedral; s++ {
+		parsed, err := ParseSymmetry(s.String())
+		if err != nil || parsed != s {
+			t.Fatalf("%s: ParseSymmetry returned %v %v", s, parsed, err)
+		}
+	}
+
+	// one symmetry per class: half turn, mirror and the full dihedral group
+	for _, s := range []Symmetry{SymmetryRotational, SymmetryVertical, SymmetryDihedral} {
+		b, err := Generate(GenerateOptions{Symmetry: s, MaxAttempts: 50, Rand: rand.New(rand.NewSource(2))})
+		if err != nil {
+			t.Fatalf("%s: %s", s, err)
+		}
+
+		if !b.IsSymmetric(s) {
+			t.Fatalf("%s: clues not symmetric: %s", s, b.GetCompact())
+		}
+	}
+}
+
+func TestIsSymmetric(t *testing.T) {
+	// A1 and J9 only
+	b, err := LoadBoard([]byte("100000000000000000000000000000000000000000000000000000000000000000000000000000002"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expecteds := map[Symmetry]bool{
+		SymmetryNone:         true,
+		SymmetryRotational:   true,
+		SymmetryVertical:     false,
+		SymmetryHorizontal:   false,
+		SymmetryDiagonal:     true,
+		SymmetryRotational90: false,
+		SymmetryDihedral:     false,
+	}
+	for s, expected := range expecteds {
+		if actual := b.IsSymmetric(s); actual != expected {
+			t.Fatalf("%s: expected %t actual %t", s, expected, actual)
+		}
+	}
+}
+
+func TestGenerateSeed(t *testing.T) {
+	var puzzles []string
+	for i := 0; i < 2; i++ {
+		opts := GenerateOptions{MinDifficulty: 11, MaxDifficulty: 25, MaxAttempts: 50, Rand: rand.New(rand.NewSource(12))}
+		b, err := Generate(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		puzzles = append(puzzles, b.GetCompact())
+	}
+
+	if puzzles[0] != puzzles[1] {
+		t.Fatalf("expected the same puzzle from the same seed, actual: %s %s", puzzles[0], puzzles[1])
+	}
+}
+
+func TestGenerateUniqueRectangle(t *testing.T) {
+	// generated puzzles are unique, so the uniqueness techniques can be required
+	opts := GenerateOptions{RequiredTechniques: []string{"UNIQUE RECTANGLE"}, MaxAttempts: 50, Rand: rand.New(rand.NewSource(3))}
+	b, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.isTargetReached(b) {
+		t.Fatalf("expected required techniques %v, actual %v", opts.RequiredTechniques, b.TechniquesUsed())
+	}
+}
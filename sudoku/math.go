@@ -1,4 +1,4 @@
-package main
+package sudoku
 
 func intersect(a []int, b []int) []int {
 	store := make(map[int]interface{})
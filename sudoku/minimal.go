@@ -0,0 +1,79 @@
+package sudoku
+
+// RedundantClues returns the positions of the clues (solved cells) which can
+// each be removed on their own while keeping a unique solution. A puzzle
+// with no redundant clues is minimal. ErrNotUnique is returned if the
+// puzzle does not have a unique solution to begin with. Uniqueness is
+// checked by brute force whatever the backend, as while digging holes.
+func (b *Board) RedundantClues() ([]int, error) {
+	unique, err := b.isUniqueWithoutClue(-1)
+	if err != nil {
+		return nil, err
+	}
+	if !unique {
+		return nil, ErrNotUnique
+	}
+
+	var list []int
+	for pos := 0; pos < 81; pos++ {
+		if b.solved[pos] == 0 {
+			continue
+		}
+
+		if unique, err = b.isUniqueWithoutClue(pos); err != nil {
+			return nil, err
+		}
+		if unique {
+			list = append(list, pos)
+		}
+	}
+	return list, nil
+}
+
+// IsMinimal returns true if the puzzle has a unique solution and no clue
+// can be removed without losing it.
+func (b *Board) IsMinimal() (bool, error) {
+	list, err := b.RedundantClues()
+	if err != nil {
+		return false, err
+	}
+	return len(list) == 0, nil
+}
+
+// isUniqueWithoutClue returns true if the clues of b, less the one at pos
+// (-1 for none), have a unique solution.
+func (b *Board) isUniqueWithoutClue(pos int) (bool, error) {
+	puzzle := []byte(b.GetCompact())
+	if pos >= 0 {
+		puzzle[pos] = '0'
+	}
+
+	b2, err := LoadBoard(puzzle)
+	if err != nil {
+		return false, err
+	}
+	return b2.isUniqueBrute(), nil
+}
+
+// minimize removes clues from a puzzle with a unique solution, in the
+// order given, until no more can be removed. Removing a clue only adds
+// solutions, so a clue kept once never becomes removable and a single pass
+// is enough. Uniqueness is checked by brute force, as while digging holes.
+func (b *Board) minimize(order []int) error {
+	for _, pos := range order {
+		if b.solved[pos] == 0 {
+			continue
+		}
+
+		puzzle := []byte(b.GetCompact())
+		puzzle[pos] = '0'
+		b2, err := LoadBoard(puzzle)
+		if err != nil {
+			return err
+		}
+		if b2.isUniqueBrute() {
+			b.solved[pos] = 0
+		}
+	}
+	return nil
+}
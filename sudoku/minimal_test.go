@@ -0,0 +1,65 @@
+package sudoku
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRedundantClues(t *testing.T) {
+	// 17 clue puzzles are always minimal
+	b, err := LoadBoard([]byte("000000010400000000020000000000050407008000300001090000300400200050100000000806000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := b.RedundantClues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Fatalf("expected no redundant clues, actual: %v", list)
+	}
+
+	// 21_ywing.txt, A1 can be removed on its own
+	b, err = LoadBoard([]byte("487300090000600271126090384705000162000200800000000009001076923300100450000053018"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list, err = b.RedundantClues(); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) == 0 || list[0] != 0 {
+		t.Fatalf("expected A1 to be redundant, actual: %v", list)
+	}
+
+	// two solutions
+	b, err = LoadBoard([]byte("007300090000600271126090384705000162000200800000000009001076923300100450000053018"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = b.RedundantClues(); err != ErrNotUnique {
+		t.Fatalf("expected ErrNotUnique, actual: %v", err)
+	}
+}
+
+func TestGenerateMinimal(t *testing.T) {
+	b, err := Generate(GenerateOptions{Minimal: true, MaxAttempts: 50, Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := LoadBoard([]byte(b.GetCompact()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	minimal, err := b2.IsMinimal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !minimal {
+		t.Fatalf("%s: expected minimal puzzle", b.GetCompact())
+	}
+
+	if _, err = Generate(GenerateOptions{Minimal: true, Symmetry: SymmetryRotational}); err == nil {
+		t.Fatal("expected error combining Minimal with a fixed symmetry")
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package sudoku
 
 import (
 	"bytes"
@@ -13,7 +13,7 @@ func satrcToPos(r int, c int) int {
 	return (r-1)*9 + (c - 1)
 }
 
-func (b *board) hasHint(satR int, satC int, v int) bool {
+func (b *Board) hasHint(satR int, satC int, v int) bool {
 	pos := satrcToPos(satR, satC)
 	if b.solved[pos] != 0 {
 		if b.solved[pos] == uint(v) {
@@ -28,7 +28,7 @@ func (b *board) hasHint(satR int, satC int, v int) bool {
 	return false
 }
 
-func (b *board) getSAT() string {
+func (b *Board) getSAT() string {
 	var clauses int
 
 	singlebuf := bytes.NewBufferString("")
@@ -0,0 +1,86 @@
+package sudoku
+
+// Trace is the record of a solve: the board as it was before the first
+// step followed by every technique application in order.
+type Trace struct {
+	StartValues     [81]uint `json:"startValues"`
+	StartCandidates [81]uint `json:"startCandidates"`
+	Steps           []Step   `json:"steps"`
+}
+
+// Step is a single application of a technique. Cells are positions 0-80
+// (row*9 + col) of the cells forming the pattern and Digits are the
+// values the pattern is based on.
+type Step struct {
+	Technique    string        `json:"technique"`
+	Description  string        `json:"description"`
+	Cells        []int         `json:"cells,omitempty"`
+	Digits       []int         `json:"digits,omitempty"`
+	Placements   []Placement   `json:"placements,omitempty"`
+	Eliminations []Elimination `json:"eliminations,omitempty"`
+}
+
+// Placement is a cell solved by a step.
+type Placement struct {
+	Pos   int `json:"pos"`
+	Value int `json:"value"`
+}
+
+// Elimination is a candidate removal made by a step. OldMask and NewMask
+// are the cell's candidates before and after, bit 0 being the digit 1.
+type Elimination struct {
+	Pos     int  `json:"pos"`
+	OldMask uint `json:"oldMask"`
+	NewMask uint `json:"newMask"`
+}
+
+// Removed returns the candidates removed from the cell.
+func (e Elimination) Removed() uint {
+	return e.OldMask & ^e.NewMask
+}
+
+func newTrace(b *Board) *Trace {
+	return &Trace{StartValues: b.solved, StartCandidates: b.blits}
+}
+
+func (t *Trace) lastStep() *Step {
+	if len(t.Steps) == 0 {
+		return nil
+	}
+	return &t.Steps[len(t.Steps)-1]
+}
+
+func (t *Trace) clone() *Trace {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	clone.Steps = make([]Step, len(t.Steps))
+	for i, step := range t.Steps {
+		// the last step may still be appended to; don't share backing arrays
+		step.Placements = append([]Placement(nil), step.Placements...)
+		step.Eliminations = append([]Elimination(nil), step.Eliminations...)
+		step.Cells = append([]int(nil), step.Cells...)
+		step.Digits = append([]int(nil), step.Digits...)
+		clone.Steps[i] = step
+	}
+	return &clone
+}
+
+// Techniques returns the distinct techniques used, in order of first use.
+func (t *Trace) Techniques() []string {
+	if t == nil {
+		return nil
+	}
+
+	var list []string
+	seen := make(map[string]interface{})
+	for _, step := range t.Steps {
+		if _, ok := seen[step.Technique]; ok {
+			continue
+		}
+		seen[step.Technique] = struct{}{}
+		list = append(list, step.Technique)
+	}
+	return list
+}
@@ -0,0 +1,42 @@
+package sudoku
+
+import (
+	"fmt"
+	"io"
+)
+
+// Print writes the trace in the text form used by the -steps flag: the
+// starting board, each step with its candidate removals, and the board
+// again after every step which removed candidates.
+func (t *Trace) Print(w io.Writer) {
+	if t == nil || len(t.Steps) == 0 {
+		return
+	}
+
+	// replay the steps on a scratch board to print intermediate states
+	b := &Board{solved: t.StartValues, blits: t.StartCandidates}
+	fmt.Fprint(w, b.GetTextBoardWithHints())
+
+	for i, step := range t.Steps {
+		if i != 0 && len(t.Steps[i-1].Eliminations) != 0 {
+			fmt.Fprintln(w)
+			fmt.Fprint(w, b.GetTextBoardWithHints())
+		}
+
+		fmt.Fprintf(w, "%s: %s\n", step.Technique, step.Description)
+
+		for _, placement := range step.Placements {
+			b.solved[placement.Pos] = uint(placement.Value)
+			b.blits[placement.Pos] = 1 << uint(placement.Value-1)
+		}
+
+		for _, elimination := range step.Eliminations {
+			fmt.Fprintf(w, "    %-7s removed from %s      old: %-17s new: %-17s\n",
+				GetBitsString(elimination.Removed()),
+				getCoords(elimination.Pos),
+				GetBitsString(elimination.OldMask),
+				GetBitsString(elimination.NewMask))
+			b.blits[elimination.Pos] = elimination.NewMask
+		}
+	}
+}